@@ -0,0 +1,113 @@
+// Candles builds up a local history of candles from the Robinhood API
+package main
+
+// usage:
+//
+// RH_USER=email@example.org RH_PASS=password go run . sync ^SP50
+// go run . --from=2021-09-01 query SPY
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/candles"
+	"github.com/tstromberg/roho/pkg/index"
+	"github.com/tstromberg/roho/pkg/roho"
+	"k8s.io/klog/v2"
+)
+
+var (
+	dirFlag      = flag.String("dir", "", "directory to store candles in (default: user cache directory)")
+	intervalFlag = flag.String("interval", string(roho.FiveMinute), "candle interval: 5minute, 10minute, 30minute, hour, day, week")
+	fromFlag     = flag.String("from", "", "first date to query (YYYY-MM-DD)")
+	toFlag       = flag.String("to", "", "date to query until (YYYY-MM-DD), exclusive")
+	resampleFlag = flag.Duration("resample", 0, "aggregate queried candles into coarser candles of this duration, such as 15m")
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	if len(flag.Args()) < 2 {
+		klog.Fatalf("usage: candles [sync|query] [symbols]")
+	}
+
+	dir := *dirFlag
+	if dir == "" {
+		d, err := candles.DefaultDir()
+		if err != nil {
+			klog.Fatalf("default dir: %v", err)
+		}
+		dir = d
+	}
+
+	s, err := candles.New(dir)
+	if err != nil {
+		klog.Fatalf("new store: %v", err)
+	}
+
+	ctx := context.Background()
+	interval := roho.Interval(*intervalFlag)
+
+	// Only syncing requires logging in; querying works offline.
+	var r *roho.Client
+	if flag.Args()[0] == "sync" {
+		r, err = roho.New(ctx, &roho.Config{})
+		if err != nil {
+			klog.Fatalf("new failed: %v", err)
+		}
+	}
+
+	syms, err := index.Resolve(ctx, r, flag.Args()[1:])
+	if err != nil {
+		klog.Fatalf("failed to resolve symbols: %v", err)
+	}
+
+	switch flag.Args()[0] {
+	case "sync":
+		added, err := candles.Sync(ctx, r, s, interval, syms)
+		if err != nil {
+			klog.Fatalf("sync failed: %v", err)
+		}
+		for _, sym := range syms {
+			klog.Infof("%s: %d new %s candles", sym, added[strings.ToUpper(sym)], interval)
+		}
+	case "query":
+		from, err := parseDate(*fromFlag)
+		if err != nil {
+			klog.Fatalf("from: %v", err)
+		}
+		to, err := parseDate(*toFlag)
+		if err != nil {
+			klog.Fatalf("to: %v", err)
+		}
+
+		for _, sym := range syms {
+			rs, err := s.Query(sym, interval, from, to)
+			if err != nil {
+				klog.Fatalf("query %s: %v", sym, err)
+			}
+			if *resampleFlag > 0 {
+				rs = candles.Resample(rs, *resampleFlag)
+			}
+			for _, r := range rs {
+				fmt.Printf("%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\t%d\n", sym, r.BeginsAt.Format(time.RFC3339), r.OpenPrice, r.HighPrice, r.LowPrice, r.ClosePrice, r.Volume)
+			}
+		}
+	default:
+		klog.Errorf("%q is an unknown verb", flag.Args()[0])
+		os.Exit(1)
+	}
+}
+
+// parseDate parses an optional date flag.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
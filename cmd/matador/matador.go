@@ -10,22 +10,37 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/tstromberg/roho/pkg/index"
 	"github.com/tstromberg/roho/pkg/roho"
+	"github.com/tstromberg/roho/pkg/screener"
 	"github.com/tstromberg/roho/pkg/strategy"
+	"github.com/tstromberg/roho/pkg/times"
 	"k8s.io/klog/v2"
 )
 
 var (
-	dryRunFlag          = flag.Bool("dry-run", false, "dry-run mode (don't buy/sell anything)")
-	strategyFlag        = flag.String("strategy", "", fmt.Sprintf("strategy to use. Choices: %v", strategy.List()))
-	pollFlag            = flag.Duration("poll", 60*time.Second, "how often to poll")
-	maxBuysFlag         = flag.Int("max-buys", 5, "maximum buys before exiting")
-	maxBuysPerPollFlag  = flag.Int("max-buys-per-poll", 1, "maximum buys per polling period")
-	maxSalesFlag        = flag.Int("max-sales", 5, "maximum sales before exiting")
-	maxSalesPerPollFlag = flag.Int("max-sales-per-poll", 1, "maximum sales per polling period")
+	dryRunFlag           = flag.Bool("dry-run", false, "dry-run mode (don't buy/sell anything)")
+	strategyFlag         = flag.String("strategy", "", fmt.Sprintf("strategy to use. Choices: %v", strategy.List()))
+	pollFlag             = flag.Duration("poll", 60*time.Second, "how often to poll")
+	maxBuysFlag          = flag.Int("max-buys", 5, "maximum buys before exiting")
+	maxBuysPerPollFlag   = flag.Int("max-buys-per-poll", 1, "maximum buys per polling period")
+	maxSalesFlag         = flag.Int("max-sales", 5, "maximum sales before exiting")
+	maxSalesPerPollFlag  = flag.Int("max-sales-per-poll", 1, "maximum sales per polling period")
+	optionsFlag          = flag.Bool("options", false, "gather option chains and positions for strategies to use")
+	optionsMaxDTEFlag    = flag.Int("options-max-dte", 45, "maximum days to expiration of options to gather")
+	cryptoFlag           = flag.String("crypto", "", "comma-separated crypto currencies to trade around the clock, such as BTC,ETH")
+	cryptoDollarsFlag    = flag.Int64("crypto-dollars", 0, "dollar amount of each crypto currency buy (crypto currencies are only sold if unset)")
+	screenFlag           = flag.String("screen", "", fmt.Sprintf("only trade symbols matching conditions, such as \"market cap > 10B and pe < 20 and rsi(14) < 30\". Metrics: %s", strings.Join(screener.Metrics(), ", ")))
+	screenRankFlag       = flag.String("screen-rank", "", "metric to rank screened symbols by, such as rsi(14), or -market_cap for descending")
+	screenLimitFlag      = flag.Int("screen-limit", 0, "maximum number of screened symbols to trade (0 for unlimited)")
+	maxQuoteAgeFlag      = flag.Duration("max-quote-age", strategy.DefaultMaxQuoteAge, "how old a quote may be while its market is open before the symbol is excluded from trading")
+	earningsBlackoutFlag = flag.Int("earnings-blackout", 0, "do not buy symbols which report earnings within this many trading days (0 to disable)")
+	earningsRefreshFlag  = flag.Duration("earnings-refresh", 24*time.Hour, "how often to refresh earnings reports gathered by --earnings-blackout")
+	researchFlag         = flag.Bool("research", false, "gather news and analyst ratings for strategies to use, and to log alongside trades")
+	researchRefreshFlag  = flag.Duration("research-refresh", time.Hour, "how often to refresh news and analyst ratings gathered by --research")
 )
 
 func main() {
@@ -43,34 +58,86 @@ func main() {
 		klog.Fatalf("new failed: %v", err)
 	}
 
+	// Trading hours account for holidays and early closes.
+	times.SetHoursSource(r.HoursSource(roho.MarketNYSE))
+
 	klog.Infof("args=%v (dry-run=%v, strategy=%v)", os.Args, *dryRunFlag, *strategyFlag)
 
-	if len(flag.Args()) < 1 {
-		klog.Fatalf("usage: matador --strategy=X [symbols]")
+	cryptos := []string{}
+	if *cryptoFlag != "" {
+		cryptos = strings.Split(*cryptoFlag, ",")
+	}
+
+	if len(flag.Args()) < 1 && len(cryptos) == 0 {
+		klog.Fatalf("usage: matador --strategy=X [--crypto=BTC] [symbols]")
 	}
 
-	syms, err := index.Resolve(ctx, flag.Args())
+	syms, err := index.Resolve(ctx, r, flag.Args())
 	if err != nil {
 		klog.Fatalf("failed to resolve symbols: %v", err)
 	}
 
-	if len(syms) == 0 {
-		klog.Errorf("no symbols were resolved. usage: matador --strategy=X [symbols]")
+	if *screenFlag != "" {
+		syms, err = screen(ctx, r, syms)
+		if err != nil {
+			klog.Fatalf("screen failed: %v", err)
+		}
+	}
+
+	if len(syms) == 0 && len(cryptos) == 0 {
+		klog.Errorf("no symbols were resolved. usage: matador --strategy=X [--crypto=BTC] [symbols]")
 		os.Exit(1)
 	}
 
-	st, err := strategy.New(strategy.Config{Client: r, Kind: *strategyFlag})
+	vals := map[string]int64{}
+	if *cryptoDollarsFlag > 0 {
+		vals[strategy.CryptoDollars] = *cryptoDollarsFlag
+	} else if len(cryptos) > 0 {
+		klog.Warningf("--crypto-dollars is not set: crypto currencies will not be bought")
+	}
+
+	st, err := strategy.New(strategy.Config{Client: r, Kind: *strategyFlag, Values: vals})
 	if err != nil {
 		klog.Errorf("strategy failed: %v", err)
 		os.Exit(1)
 	}
 
-	loop(ctx, r, st, syms)
+	loop(ctx, r, st, syms, cryptos)
+
+	if err := r.InstrumentCache.Save(); err != nil {
+		klog.Warningf("unable to save instrument cache: %v", err)
+	}
+}
+
+// screen narrows down symbols to those matching --screen, ranked by --screen-rank.
+func screen(ctx context.Context, r *roho.Client, syms []string) ([]string, error) {
+	cs, err := screener.Parse(*screenFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := screener.Screen{Conditions: cs, Limit: *screenLimitFlag}
+	if *screenRankFlag != "" {
+		m, err := screener.ParseRankMetric(strings.TrimPrefix(*screenRankFlag, "-"))
+		if err != nil {
+			return nil, err
+		}
+		sc.RankBy = &m
+		sc.Descending = strings.HasPrefix(*screenRankFlag, "-")
+	}
+
+	klog.Infof("Screening %d symbols for %q ...", len(syms), *screenFlag)
+	rs, err := screener.Run(ctx, r, sc, syms)
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("%d symbols passed the screen: %v", len(rs), screener.Symbols(rs))
+	return screener.Symbols(rs), nil
 }
 
 func trade(ctx context.Context, r *roho.Client, t strategy.Trade, dryRun bool) error {
 	act := "Selling"
-	if t.Order.Side == roho.Buy {
+	if t.Side() == roho.Buy {
 		act = "Buying"
 	}
 
@@ -78,15 +145,48 @@ func trade(ctx context.Context, r *roho.Client, t strategy.Trade, dryRun bool) e
 		act = "[DRY RUN] " + act
 	}
 
+	if t.IsCrypto() {
+		klog.Infof("%s %s of %q at %.2f: %q ...", act, cryptoAmount(t.CryptoOrder), t.Instrument.Symbol, t.CryptoOrder.Price, t.Reason)
+		if dryRun {
+			return nil
+		}
+		out, err := r.CryptoOrder(ctx, *t.CryptoPair, t.CryptoOrder)
+		klog.Infof("crypto order result: %+v", out)
+		return err
+	}
+
+	if t.IsOption() {
+		klog.Infof("%s %.0f of a %d-leg %q option order at %.2f: %q ...", act, t.OptionOrder.Quantity, len(t.OptionLegs), t.Instrument.Symbol, t.OptionOrder.Price, t.Reason)
+		if dryRun {
+			return nil
+		}
+		out, err := r.OrderOptionLegs(ctx, t.OptionLegs, t.OptionOrder)
+		klog.Infof("option order result: %s", out)
+		return err
+	}
+
 	klog.Infof("%s %d shares of %q at %.2f: %q ...", act, t.Order.Quantity, t.Instrument.Symbol, t.Order.Price, t.Reason)
 	if dryRun {
 		return nil
 	}
+
+	// Cached instruments may be out of date, so tradability is checked afresh.
+	if err := r.CheckTradable(ctx, t.Instrument); err != nil {
+		return err
+	}
 	out, err := r.Order(ctx, t.Instrument.URL, t.Instrument.Symbol, t.Order)
 	klog.Infof("order result: %+v", out)
 	return err
 }
 
+// cryptoAmount describes how much of a crypto currency an order is for.
+func cryptoAmount(o roho.CryptoOrderOpts) string {
+	if o.Quantity > 0 {
+		return fmt.Sprintf("%g units", o.Quantity)
+	}
+	return fmt.Sprintf("$%.2f", o.AmountInDollars)
+}
+
 type Counter struct {
 	TotalBuys  int
 	TotalSales int
@@ -95,35 +195,80 @@ type Counter struct {
 	Polls      int
 }
 
-func loop(ctx context.Context, r *roho.Client, st strategy.Strategy, syms []string) {
-	klog.Infof("%q loop has begun with %d symbols!", st, len(syms))
+func loop(ctx context.Context, r *roho.Client, st strategy.Strategy, syms []string, cryptos []string) {
+	klog.Infof("%q loop has begun with %d symbols and %d crypto currencies!", st, len(syms), len(cryptos))
 	counter := &Counter{}
 
 	klog.Infof("Gathering live data for %d symbols ...", len(syms))
 	tctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer cancel()
 
-	combined, err := strategy.LiveData(tctx, r, syms)
+	combined := []*strategy.CombinedStock{}
+	if len(syms) > 0 {
+		var err error
+		combined, err = strategy.LiveData(tctx, r, syms)
+		if err != nil {
+			klog.Errorf("live data: %v", err)
+			return
+		}
+
+		if err := addOptions(tctx, r, combined); err != nil {
+			klog.Errorf("options: %v", err)
+			return
+		}
+
+		if err := addResearch(tctx, r, combined); err != nil {
+			klog.Errorf("research: %v", err)
+			return
+		}
+
+		if err := addEarnings(tctx, r, combined); err != nil {
+			klog.Errorf("earnings: %v", err)
+			return
+		}
+	}
+
+	crypto, err := strategy.CryptoLiveData(tctx, r, cryptos)
 	if err != nil {
-		klog.Errorf("live data: %v", err)
+		klog.Errorf("crypto live data: %v", err)
 		return
 	}
 
 	for {
 		counter.Polls++
 
+		// Equity and crypto data are updated independently, so that a failure
+		// of one only skips trading the stale half this poll.
+		stocks, cryptoStocks := combined, crypto
 		if counter.Polls > 1 {
 			klog.Infof("%d buys, %d sells. Sleeping for %s...", counter.TotalBuys, counter.TotalSales, pollFlag)
 			time.Sleep(*pollFlag)
-			klog.Infof("Updating data for %d symbols ...", len(combined))
-			combined, err = strategy.UpdateData(ctx, r, combined)
+
+			stocks, err = updateStocks(ctx, r, combined)
 			if err != nil {
-				klog.Errorf("failed to update data: %v", err)
-				continue
+				klog.Errorf("failed to update stock data: %v", err)
+			} else {
+				combined = stocks
+			}
+
+			// Crypto currencies trade around the clock, regardless of equity market hours.
+			if len(crypto) > 0 {
+				klog.Infof("Updating data for %d crypto currencies ...", len(crypto))
+				cryptoStocks, err = strategy.UpdateCryptoData(ctx, r, crypto)
+				if err != nil {
+					klog.Errorf("failed to update crypto data: %v", err)
+				} else {
+					crypto = cryptoStocks
+				}
 			}
 		}
 
-		cont, err := check(ctx, r, st, combined, *dryRunFlag, counter)
+		all, excluded := strategy.Tradable(append(append([]*strategy.CombinedStock{}, stocks...), cryptoStocks...), *maxQuoteAgeFlag)
+		for _, s := range excluded {
+			klog.Warningf("%s: excluded from trading: %v", s.Instrument.Symbol, s.QuoteProblem)
+		}
+
+		cont, err := check(ctx, r, st, all, *dryRunFlag, counter)
 		if err != nil {
 			klog.Errorf("check failed: %v", err)
 			continue
@@ -136,6 +281,73 @@ func loop(ctx context.Context, r *roho.Client, st strategy.Strategy, syms []stri
 	}
 }
 
+// updateStocks refreshes the data of the combined stocks, returning nil if any
+// of it could not be updated.
+func updateStocks(ctx context.Context, r *roho.Client, combined []*strategy.CombinedStock) ([]*strategy.CombinedStock, error) {
+	if len(combined) == 0 {
+		return combined, nil
+	}
+
+	klog.Infof("Updating data for %d symbols ...", len(combined))
+	updated, err := strategy.UpdateData(ctx, r, combined)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addOptions(ctx, r, updated); err != nil {
+		return nil, fmt.Errorf("options: %w", err)
+	}
+
+	if err := addResearch(ctx, r, updated); err != nil {
+		return nil, fmt.Errorf("research: %w", err)
+	}
+
+	if err := addEarnings(ctx, r, updated); err != nil {
+		return nil, fmt.Errorf("earnings: %w", err)
+	}
+	return updated, nil
+}
+
+// addOptions attaches options data to the combined stocks if --options is set.
+func addOptions(ctx context.Context, r *roho.Client, combined []*strategy.CombinedStock) error {
+	if !*optionsFlag {
+		return nil
+	}
+	klog.Infof("Gathering options data for %d symbols ...", len(combined))
+	return strategy.AddOptions(ctx, r, combined, roho.ChainQuery{MaxDTE: *optionsMaxDTEFlag})
+}
+
+// addEarnings attaches earnings reports to the combined stocks if
+// --earnings-blackout is set, refreshing them every --earnings-refresh.
+func addEarnings(ctx context.Context, r *roho.Client, combined []*strategy.CombinedStock) error {
+	if *earningsBlackoutFlag <= 0 {
+		return nil
+	}
+	klog.Infof("Gathering earnings reports for %d symbols ...", len(combined))
+	return strategy.AddEarnings(ctx, r, combined, *earningsRefreshFlag)
+}
+
+// addResearch attaches news and analyst ratings to the combined stocks if
+// --research is set, refreshing them every --research-refresh.
+func addResearch(ctx context.Context, r *roho.Client, combined []*strategy.CombinedStock) error {
+	if !*researchFlag {
+		return nil
+	}
+	klog.Infof("Gathering news and ratings for %d symbols ...", len(combined))
+	return strategy.AddResearch(ctx, r, combined, *researchRefreshFlag)
+}
+
+// logResearch logs the analyst ratings and latest headline of a stock, if known.
+func logResearch(s *strategy.CombinedStock) {
+	if s.Ratings != nil && s.Ratings.Summary.Total() > 0 {
+		klog.Infof("%s analyst ratings: %s", s.Instrument.Symbol, s.Ratings.Summary)
+	}
+	if len(s.News) > 0 {
+		n := s.News[0]
+		klog.Infof("%s latest news (%s, %s): %q", s.Instrument.Symbol, n.Source, n.PublishedAt.Format(time.RFC822), n.Title)
+	}
+}
+
 func check(ctx context.Context, r *roho.Client, st strategy.Strategy, combined []*strategy.CombinedStock, dryRun bool, count *Counter) (bool, error) {
 	klog.Infof("Calculating trades for %d stocks ...", len(combined))
 	ts, err := st.Trades(ctx, combined)
@@ -154,8 +366,20 @@ func check(ctx context.Context, r *roho.Client, st strategy.Strategy, combined [
 	count.PollSales = 0
 	count.PollBuys = 0
 
+	byURL := map[string]*strategy.CombinedStock{}
+	for _, s := range combined {
+		if s.Instrument != nil {
+			byURL[s.Instrument.URL] = s
+		}
+	}
+
 	for _, t := range ts {
-		if t.Order.Side == roho.Buy {
+		if t.Side() == roho.Buy {
+			if s, ok := byURL[t.Instrument.URL]; ok && *earningsBlackoutFlag > 0 && s.ReportsEarningsWithin(time.Now(), *earningsBlackoutFlag) {
+				klog.Warningf(" -> BUY %s (ignoring, reports earnings within earnings-blackout=%d trading days): %+v", t.Instrument.Symbol, *earningsBlackoutFlag, t)
+				continue
+			}
+
 			if count.PollBuys+1 > *maxBuysPerPollFlag {
 				klog.Warningf(" -> BUY %s (ignoring, over max-buys-per-poll=%d): %+v", t.Instrument.Symbol, *maxBuysPerPollFlag, t)
 				continue
@@ -169,7 +393,7 @@ func check(ctx context.Context, r *roho.Client, st strategy.Strategy, combined [
 			count.TotalBuys++
 		}
 
-		if t.Order.Side == roho.Sell {
+		if t.Side() == roho.Sell {
 			if count.PollSales+1 > *maxSalesPerPollFlag {
 				klog.Warningf(" -> SELL %s (ignoring, over max-sales-per-poll=%d): %+v", t.Instrument.Symbol, *maxSalesPerPollFlag, t)
 				continue
@@ -180,6 +404,10 @@ func check(ctx context.Context, r *roho.Client, st strategy.Strategy, combined [
 			}
 		}
 
+		if s, ok := byURL[t.Instrument.URL]; ok {
+			logResearch(s)
+		}
+
 		if err := trade(ctx, r, t, dryRun); err != nil {
 			return true, fmt.Errorf("trade failed: %w", err)
 		}
@@ -6,7 +6,6 @@ require (
 	github.com/PuerkitoBio/goquery v1.7.1
 	github.com/google/go-cmp v0.5.1
 	github.com/google/uuid v1.3.0
-	github.com/hashicorp/go-multierror v1.1.1
 	github.com/pkg/errors v0.9.1
 	golang.org/x/oauth2 v0.0.0-20210819190943-2bc19b11175f
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
@@ -0,0 +1,164 @@
+// Package candles persists historical candles locally, so that intraday
+// history outlives the sliding window offered by the Robinhood API.
+package candles
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// Store is a persistent collection of historical candles, kept as one JSON
+// file per symbol and interval.
+type Store struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// DefaultDir returns the default location of the candle store.
+func DefaultDir() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("user cache dir: %w", err)
+	}
+	return filepath.Join(d, "roho", "candles"), nil
+}
+
+// New returns a Store rooted at dir, creating it if necessary.
+func New(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
+	}
+	return &Store{Dir: dir}, nil
+}
+
+func (s *Store) path(symbol string, interval roho.Interval) string {
+	return filepath.Join(s.Dir, string(interval), strings.ToUpper(symbol)+".json")
+}
+
+// Load returns all stored candles for a symbol and interval, oldest first.
+func (s *Store) Load(symbol string, interval roho.Interval) ([]roho.HistoricalRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(symbol, interval)
+}
+
+func (s *Store) load(symbol string, interval roho.Interval) ([]roho.HistoricalRecord, error) {
+	bs, err := ioutil.ReadFile(s.path(symbol, interval))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rs []roho.HistoricalRecord
+	if err := json.Unmarshal(bs, &rs); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", s.path(symbol, interval), err)
+	}
+	return rs, nil
+}
+
+// save atomically replaces the stored candles for a symbol and interval.
+func (s *Store) save(symbol string, interval roho.Interval, rs []roho.HistoricalRecord) error {
+	p := s.path(symbol, interval)
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	bs, err := json.Marshal(rs)
+	if err != nil {
+		return err
+	}
+
+	tmp := p + ".tmp"
+	if err := ioutil.WriteFile(tmp, bs, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+// Merge adds candles to the store, replacing any stored candles that begin at
+// the same time, and returns the number of new candles.
+func (s *Store) Merge(symbol string, interval roho.Interval, rs []roho.HistoricalRecord) (int, error) {
+	if len(rs) == 0 {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, err := s.load(symbol, interval)
+	if err != nil {
+		return 0, err
+	}
+
+	merged, added := merge(old, rs)
+	return added, s.save(symbol, interval, merged)
+}
+
+// merge combines two sets of candles, preferring b when both begin at the
+// same time. It returns the merged candles sorted by time, and the number of
+// candles in b that were not in a.
+func merge(a, b []roho.HistoricalRecord) ([]roho.HistoricalRecord, int) {
+	byTime := map[int64]roho.HistoricalRecord{}
+	for _, r := range a {
+		byTime[r.BeginsAt.UnixNano()] = r
+	}
+
+	added := 0
+	for _, r := range b {
+		k := r.BeginsAt.UnixNano()
+		if _, ok := byTime[k]; !ok {
+			added++
+		}
+		byTime[k] = r
+	}
+
+	out := make([]roho.HistoricalRecord, 0, len(byTime))
+	for _, r := range byTime {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].BeginsAt.Before(out[j].BeginsAt) })
+	return out, added
+}
+
+// Latest returns the start time of the most recent stored candle, or the zero
+// time if none are stored.
+func (s *Store) Latest(symbol string, interval roho.Interval) (time.Time, error) {
+	rs, err := s.Load(symbol, interval)
+	if err != nil || len(rs) == 0 {
+		return time.Time{}, err
+	}
+	return rs[len(rs)-1].BeginsAt, nil
+}
+
+// Query returns the stored candles for a symbol and interval that begin
+// within [from, to). A zero from or to is unbounded.
+func (s *Store) Query(symbol string, interval roho.Interval, from, to time.Time) ([]roho.HistoricalRecord, error) {
+	rs, err := s.Load(symbol, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []roho.HistoricalRecord{}
+	for _, r := range rs {
+		if !from.IsZero() && r.BeginsAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !r.BeginsAt.Before(to) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
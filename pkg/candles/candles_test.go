@@ -0,0 +1,88 @@
+package candles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+func bar(t time.Time, price float64) roho.HistoricalRecord {
+	return roho.HistoricalRecord{BeginsAt: t, OpenPrice: price, ClosePrice: price, HighPrice: price, LowPrice: price}
+}
+
+func TestStoreMergeQuery(t *testing.T) {
+	s, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	t0 := time.Date(2021, 9, 14, 13, 30, 0, 0, time.UTC)
+	at := func(i int) time.Time { return t0.Add(time.Duration(i) * 5 * time.Minute) }
+
+	n, err := s.Merge("spy", roho.FiveMinute, []roho.HistoricalRecord{bar(at(0), 1), bar(at(1), 2), bar(at(2), 3)})
+	if err != nil || n != 3 {
+		t.Fatalf("Merge() = %d, %v; want 3, nil", n, err)
+	}
+
+	// Overlapping sync: the incomplete bar at(2) is replaced, and two bars are new.
+	n, err = s.Merge("SPY", roho.FiveMinute, []roho.HistoricalRecord{bar(at(2), 3.5), bar(at(3), 4), bar(at(4), 5)})
+	if err != nil || n != 2 {
+		t.Fatalf("Merge() = %d, %v; want 2, nil", n, err)
+	}
+
+	latest, err := s.Latest("SPY", roho.FiveMinute)
+	if err != nil || !latest.Equal(at(4)) {
+		t.Errorf("Latest() = %s, %v; want %s", latest, err, at(4))
+	}
+
+	rs, err := s.Query("SPY", roho.FiveMinute, at(1), at(4))
+	if err != nil {
+		t.Fatalf("Query() returned error: %v", err)
+	}
+	got := []float64{}
+	for _, r := range rs {
+		got = append(got, r.ClosePrice)
+	}
+	if len(got) != 3 || got[0] != 2 || got[1] != 3.5 || got[2] != 4 {
+		t.Errorf("Query() close prices = %v, want [2 3.5 4]", got)
+	}
+
+	rs, err = s.Query("QQQ", roho.FiveMinute, time.Time{}, time.Time{})
+	if err != nil || len(rs) != 0 {
+		t.Errorf("Query() of unknown symbol = %v, %v; want empty", rs, err)
+	}
+}
+
+func TestSpanFor(t *testing.T) {
+	now := time.Date(2021, 9, 14, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		interval roho.Interval
+		latest   time.Time
+		now      time.Time
+		want     roho.Span
+	}{
+		{interval: roho.FiveMinute, want: roho.Week},
+		{interval: roho.FiveMinute, latest: now.Add(-2 * time.Hour), want: roho.Day},
+		// Monday at 15:00 New York time is within a day of Tuesday at 09:40, but in an earlier session.
+		{interval: roho.FiveMinute, latest: time.Date(2021, 9, 13, 19, 0, 0, 0, time.UTC), now: time.Date(2021, 9, 14, 13, 40, 0, 0, time.UTC), want: roho.Week},
+		{interval: roho.FiveMinute, latest: now.Add(-72 * time.Hour), want: roho.Week},
+		{interval: roho.FiveMinute, latest: now.Add(-30 * 24 * time.Hour), want: roho.Week},
+		{interval: roho.Day, latest: now.Add(-60 * 24 * time.Hour), want: roho.ThreeMonth},
+		{interval: roho.Day, want: roho.FiveYear},
+	}
+
+	for _, tc := range tests {
+		if tc.now.IsZero() {
+			tc.now = now
+		}
+		got, err := spanFor(tc.interval, tc.latest, tc.now)
+		if err != nil {
+			t.Errorf("spanFor(%s, %s) returned error: %v", tc.interval, tc.latest, err)
+		}
+		if got != tc.want {
+			t.Errorf("spanFor(%s, %s) = %s, want %s", tc.interval, tc.latest, got, tc.want)
+		}
+	}
+}
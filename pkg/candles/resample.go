@@ -0,0 +1,176 @@
+package candles
+
+import (
+	"sort"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+// Session values found in HistoricalRecord.Session.
+const (
+	PreMarket  = roho.SessionPre
+	Regular    = roho.SessionRegular
+	PostMarket = roho.SessionPost
+)
+
+// nyLoc returns the *time.Location of New_York, where sessions are defined.
+func nyLoc() *time.Location {
+	et, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return et
+}
+
+// session returns the trading session a candle belongs to, deriving it from
+// the time of day if the API did not provide one.
+func session(r roho.HistoricalRecord) string {
+	if r.Session != "" {
+		return r.Session
+	}
+
+	m := times.MinuteOfDay(r.BeginsAt.In(nyLoc()))
+	switch {
+	case m < times.MinOpen:
+		return PreMarket
+	case m < times.MinClose:
+		return Regular
+	default:
+		return PostMarket
+	}
+}
+
+// sessionStart returns the time at which the session containing a candle
+// began. Regular session bars are aligned to the opening bell, so that hourly
+// bars begin at 9:30, and extended hours bars are aligned to midnight.
+func sessionStart(r roho.HistoricalRecord) time.Time {
+	t := r.BeginsAt.In(nyLoc())
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if session(r) == Regular {
+		return day.Add(times.MinOpen * time.Minute)
+	}
+	return day
+}
+
+// SplitSessions separates regular trading hours candles from pre and post
+// market candles.
+func SplitSessions(rs []roho.HistoricalRecord) (regular, extended []roho.HistoricalRecord) {
+	regular = []roho.HistoricalRecord{}
+	extended = []roho.HistoricalRecord{}
+	for _, r := range rs {
+		if session(r) == Regular {
+			regular = append(regular, r)
+		} else {
+			extended = append(extended, r)
+		}
+	}
+	return regular, extended
+}
+
+// Resample aggregates intraday candles into candles of a coarser duration,
+// such as 15 or 30 minute candles from 5 minute candles. Candles are never
+// aggregated across sessions, so the last candle of a session may cover less
+// than d.
+func Resample(rs []roho.HistoricalRecord, d time.Duration) []roho.HistoricalRecord {
+	return aggregate(rs, func(r roho.HistoricalRecord) (time.Time, string) {
+		start := sessionStart(r)
+		n := r.BeginsAt.Sub(start) / d
+		return start.Add(n * d), session(r)
+	})
+}
+
+// calendarDay returns midnight UTC of the day a daily candle covers. Daily
+// candles begin at midnight UTC, which is the previous evening in New York, so
+// they are bucketed by their UTC date.
+func calendarDay(r roho.HistoricalRecord) time.Time {
+	t := r.BeginsAt.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Weekly aggregates daily candles into weekly candles beginning on Monday.
+func Weekly(rs []roho.HistoricalRecord) []roho.HistoricalRecord {
+	return aggregate(rs, func(r roho.HistoricalRecord) (time.Time, string) {
+		day := calendarDay(r)
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset), r.Session
+	})
+}
+
+// Monthly aggregates daily candles into calendar month candles.
+func Monthly(rs []roho.HistoricalRecord) []roho.HistoricalRecord {
+	return aggregate(rs, func(r roho.HistoricalRecord) (time.Time, string) {
+		day := calendarDay(r)
+		return day.AddDate(0, 0, 1-day.Day()), r.Session
+	})
+}
+
+// aggregate combines candles sharing the same bucket, as returned by key, into
+// a single OHLCV candle beginning at the start of the bucket.
+func aggregate(rs []roho.HistoricalRecord, key func(roho.HistoricalRecord) (time.Time, string)) []roho.HistoricalRecord {
+	sorted := make([]roho.HistoricalRecord, len(rs))
+	copy(sorted, rs)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].BeginsAt.Before(sorted[j].BeginsAt) })
+
+	out := []roho.HistoricalRecord{}
+	var last time.Time
+	var lastSession string
+
+	for _, r := range sorted {
+		start, sess := key(r)
+		if len(out) == 0 || !start.Equal(last) || sess != lastSession {
+			last, lastSession = start, sess
+			out = append(out, roho.HistoricalRecord{
+				BeginsAt:     start.UTC(),
+				OpenPrice:    r.OpenPrice,
+				ClosePrice:   r.ClosePrice,
+				HighPrice:    r.HighPrice,
+				LowPrice:     r.LowPrice,
+				Volume:       r.Volume,
+				Session:      r.Session,
+				Interpolated: r.Interpolated,
+			})
+			continue
+		}
+
+		c := &out[len(out)-1]
+		c.ClosePrice = r.ClosePrice
+		if r.HighPrice > c.HighPrice {
+			c.HighPrice = r.HighPrice
+		}
+		if r.LowPrice < c.LowPrice {
+			c.LowPrice = r.LowPrice
+		}
+		c.Volume += r.Volume
+		c.Interpolated = c.Interpolated && r.Interpolated
+	}
+	return out
+}
+
+// FillGaps inserts a flat, interpolated candle at the previous close for each
+// missing interval of duration d within a session. Gaps between sessions, such
+// as overnight or across a weekend, are left alone.
+func FillGaps(rs []roho.HistoricalRecord, d time.Duration) []roho.HistoricalRecord {
+	out := []roho.HistoricalRecord{}
+	for i, r := range rs {
+		if i > 0 {
+			prev := rs[i-1]
+			if session(prev) == session(r) && sessionStart(prev).Equal(sessionStart(r)) {
+				for t := prev.BeginsAt.Add(d); t.Before(r.BeginsAt); t = t.Add(d) {
+					out = append(out, roho.HistoricalRecord{
+						BeginsAt:     t,
+						OpenPrice:    prev.ClosePrice,
+						ClosePrice:   prev.ClosePrice,
+						HighPrice:    prev.ClosePrice,
+						LowPrice:     prev.ClosePrice,
+						Session:      prev.Session,
+						Interpolated: true,
+					})
+				}
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package candles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+func ohlcv(t time.Time, o, h, l, c float64, v int64, session string) roho.HistoricalRecord {
+	return roho.HistoricalRecord{BeginsAt: t, OpenPrice: o, HighPrice: h, LowPrice: l, ClosePrice: c, Volume: v, Session: session}
+}
+
+func TestResample(t *testing.T) {
+	// 9:20 through 9:45 in New York: two pre-market bars, then four regular bars.
+	t0 := time.Date(2021, 9, 14, 13, 20, 0, 0, time.UTC)
+	at := func(i int) time.Time { return t0.Add(time.Duration(i) * 5 * time.Minute) }
+
+	rs := []roho.HistoricalRecord{
+		ohlcv(at(0), 10, 11, 9, 10, 100, "pre"),
+		ohlcv(at(1), 10, 12, 10, 11, 100, "pre"),
+		ohlcv(at(2), 11, 13, 11, 12, 1000, "reg"),
+		ohlcv(at(3), 12, 14, 10, 13, 1000, "reg"),
+		ohlcv(at(4), 13, 13, 12, 12, 1000, "reg"),
+		ohlcv(at(5), 12, 15, 12, 14, 1000, "reg"),
+	}
+
+	got := Resample(rs, 15*time.Minute)
+	want := []roho.HistoricalRecord{
+		ohlcv(at(-1), 10, 12, 9, 11, 200, "pre"),
+		ohlcv(at(2), 11, 14, 10, 12, 3000, "reg"),
+		ohlcv(at(5), 12, 15, 12, 14, 1000, "reg"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Resample() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWeekly(t *testing.T) {
+	// Thursday 2021-09-09 through Tuesday 2021-09-14, beginning at midnight UTC as the API returns them.
+	day := func(d int) time.Time { return time.Date(2021, 9, d, 0, 0, 0, 0, time.UTC) }
+
+	rs := []roho.HistoricalRecord{
+		ohlcv(day(13), 5, 6, 4, 5, 10, "reg"),
+		ohlcv(day(9), 1, 2, 1, 2, 10, "reg"),
+		ohlcv(day(10), 2, 3, 1, 3, 10, "reg"),
+		ohlcv(day(14), 5, 8, 5, 7, 10, "reg"),
+	}
+
+	got := Weekly(rs)
+	want := []roho.HistoricalRecord{
+		ohlcv(day(6), 1, 3, 1, 3, 20, "reg"),
+		ohlcv(day(13), 5, 8, 4, 7, 20, "reg"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Weekly() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMonthly(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2021, m, d, 0, 0, 0, 0, time.UTC) }
+
+	rs := []roho.HistoricalRecord{
+		ohlcv(day(8, 31), 1, 2, 1, 2, 10, "reg"),
+		ohlcv(day(9, 1), 3, 4, 3, 4, 10, "reg"),
+		ohlcv(day(9, 30), 4, 6, 2, 5, 10, "reg"),
+		ohlcv(day(10, 1), 5, 5, 5, 5, 10, "reg"),
+	}
+
+	got := Monthly(rs)
+	want := []roho.HistoricalRecord{
+		ohlcv(day(8, 1), 1, 2, 1, 2, 10, "reg"),
+		ohlcv(day(9, 1), 3, 6, 2, 5, 20, "reg"),
+		ohlcv(day(10, 1), 5, 5, 5, 5, 10, "reg"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Monthly() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFillGaps(t *testing.T) {
+	t0 := time.Date(2021, 9, 14, 13, 30, 0, 0, time.UTC)
+	at := func(i int) time.Time { return t0.Add(time.Duration(i) * 5 * time.Minute) }
+
+	rs := []roho.HistoricalRecord{
+		ohlcv(at(0), 10, 11, 9, 10, 100, "reg"),
+		ohlcv(at(3), 11, 12, 10, 11, 100, "reg"),
+		// The next day's session is not back-filled.
+		ohlcv(at(3).Add(24*time.Hour), 12, 12, 12, 12, 100, "reg"),
+	}
+
+	flat := roho.HistoricalRecord{OpenPrice: 10, HighPrice: 10, LowPrice: 10, ClosePrice: 10, Session: "reg", Interpolated: true}
+	f1, f2 := flat, flat
+	f1.BeginsAt = at(1)
+	f2.BeginsAt = at(2)
+
+	got := FillGaps(rs, 5*time.Minute)
+	want := []roho.HistoricalRecord{rs[0], f1, f2, rs[1], rs[2]}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FillGaps() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSplitSessions(t *testing.T) {
+	rs := []roho.HistoricalRecord{
+		ohlcv(time.Date(2021, 9, 14, 12, 0, 0, 0, time.UTC), 1, 1, 1, 1, 1, "pre"),
+		ohlcv(time.Date(2021, 9, 14, 14, 0, 0, 0, time.UTC), 2, 2, 2, 2, 2, "reg"),
+		// No session: 16:30 in New York is post-market.
+		ohlcv(time.Date(2021, 9, 14, 20, 30, 0, 0, time.UTC), 3, 3, 3, 3, 3, ""),
+	}
+
+	reg, ext := SplitSessions(rs)
+	if diff := cmp.Diff(rs[1:2], reg); diff != "" {
+		t.Errorf("SplitSessions() regular mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]roho.HistoricalRecord{rs[0], rs[2]}, ext); diff != "" {
+		t.Errorf("SplitSessions() extended mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,94 @@
+package candles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+// spanFor returns the shortest span of an interval that reaches back to the
+// latest stored candle, or the longest span if nothing is stored or the gap
+// is too large to fill.
+func spanFor(interval roho.Interval, latest, now time.Time) (roho.Span, error) {
+	ss := roho.SpansFor(interval)
+	if len(ss) == 0 {
+		return "", fmt.Errorf("unsupported interval %q", interval)
+	}
+
+	if !latest.IsZero() {
+		gap := now.Sub(latest)
+		for _, s := range ss {
+			// The day span only covers the current trading session, rather than the last 24 hours.
+			if s == roho.Day && !sameSession(latest, now) {
+				continue
+			}
+			if s.Duration() >= gap {
+				return s, nil
+			}
+		}
+	}
+	return ss[len(ss)-1], nil
+}
+
+// sameSession returns whether two times fall on the same trading day in New York.
+func sameSession(a, b time.Time) bool {
+	ha := times.HoursOn(a)
+	return ha.IsOpen && ha.Date.Equal(times.HoursOn(b).Date)
+}
+
+// Sync fetches candles for the symbols that are newer than those already
+// stored, and merges them into the store. It returns the number of new
+// candles per symbol.
+func Sync(ctx context.Context, c *roho.Client, s *Store, interval roho.Interval, symbols []string) (map[string]int, error) {
+	now := time.Now()
+
+	// Group symbols by the span required to catch them up, so that they may be fetched together.
+	bySpan := map[roho.Span][]string{}
+	for _, sym := range symbols {
+		latest, err := s.Latest(sym, interval)
+		if err != nil {
+			return nil, fmt.Errorf("latest %s: %w", sym, err)
+		}
+
+		span, err := spanFor(interval, latest, now)
+		if err != nil {
+			return nil, err
+		}
+		bySpan[span] = append(bySpan[span], sym)
+	}
+
+	added := map[string]int{}
+	for span, syms := range bySpan {
+		hs, err := c.HistoricalsParams(ctx, roho.HistoricalParams{Interval: interval, Span: span}, syms)
+		if err != nil {
+			return added, fmt.Errorf("historicals: %w", err)
+		}
+
+		for _, h := range hs {
+			latest, err := s.Latest(h.Symbol, interval)
+			if err != nil {
+				return added, fmt.Errorf("latest %s: %w", h.Symbol, err)
+			}
+
+			// The latest stored candle may have been incomplete, so it is replaced as well.
+			rs := []roho.HistoricalRecord{}
+			for _, r := range h.Records {
+				if !r.BeginsAt.Before(latest) {
+					rs = append(rs, r)
+				}
+			}
+
+			n, err := s.Merge(h.Symbol, interval, rs)
+			if err != nil {
+				return added, fmt.Errorf("merge %s: %w", h.Symbol, err)
+			}
+			added[strings.ToUpper(h.Symbol)] = n
+		}
+	}
+
+	return added, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/tstromberg/roho/pkg/roho"
 )
 
 // TODO: NDX, Russel 2000, DJI, Nasdaq Composite
@@ -56,7 +57,22 @@ func SP50(ctx context.Context) ([]string, error) {
 	return symbols[0:50], nil
 }
 
-func Resolve(ctx context.Context, syms []string) ([]string, error) {
+// takesArg is whether each index token requires an argument, such as the tag
+// of ^TAG:technology, or takes none.
+var takesArg = map[string]bool{
+	"^SP500":       false,
+	"^SP50":        false,
+	"^MOVERS_UP":   false,
+	"^MOVERS_DOWN": false,
+	"^TAG":         true,
+	"^WATCHLIST":   true,
+}
+
+// Resolve expands index tokens among a list of symbols into the symbols they
+// represent. Tokens which are resolved through Robinhood, such as ^MOVERS_UP,
+// ^TAG:technology or ^WATCHLIST:Default, require a client; r may otherwise be
+// nil.
+func Resolve(ctx context.Context, r *roho.Client, syms []string) ([]string, error) {
 	rs := []string{}
 	for _, s := range syms {
 		if !strings.HasPrefix(s, "^") {
@@ -64,23 +80,92 @@ func Resolve(ctx context.Context, syms []string) ([]string, error) {
 			continue
 		}
 
-		switch s {
+		name, arg, hasArg := s, "", false
+		if i := strings.Index(s, ":"); i > 0 {
+			name, arg, hasArg = s[:i], s[i+1:], true
+		}
+
+		needsArg, ok := takesArg[name]
+		switch {
+		case !ok:
+			return rs, fmt.Errorf("unknown index: %q", s)
+		case needsArg && arg == "":
+			return rs, fmt.Errorf("unknown index: %q: %s requires an argument, such as %s:name", s, name, name)
+		case !needsArg && hasArg:
+			return rs, fmt.Errorf("unknown index: %q: %s takes no argument", s, name)
+		}
+
+		var found []string
+		var err error
+
+		switch name {
 		case "^SP500":
-			sp, err := SP500(ctx)
-			if err != nil {
-				return rs, err
-			}
-			rs = append(rs, sp...)
+			found, err = SP500(ctx)
 		case "^SP50":
-			sp, err := SP50(ctx)
-			if err != nil {
-				return rs, err
+			found, err = SP50(ctx)
+		case "^MOVERS_UP", "^MOVERS_DOWN", "^TAG", "^WATCHLIST":
+			if r == nil {
+				return rs, fmt.Errorf("%q requires a Robinhood client", s)
 			}
-			rs = append(rs, sp...)
+			found, err = resolveRobinhood(ctx, r, name, arg)
 		default:
 			return rs, fmt.Errorf("unknown index: %q", s)
 		}
+
+		if err != nil {
+			return rs, fmt.Errorf("%s: %w", s, err)
+		}
+		rs = append(rs, found...)
 	}
 
 	return rs, nil
 }
+
+// resolveRobinhood returns the symbols of a Robinhood-provided collection.
+func resolveRobinhood(ctx context.Context, r *roho.Client, name string, arg string) ([]string, error) {
+	switch name {
+	case "^MOVERS_UP", "^MOVERS_DOWN":
+		dir := roho.MoversUp
+		if name == "^MOVERS_DOWN" {
+			dir = roho.MoversDown
+		}
+		ms, err := r.Movers(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		syms := []string{}
+		for _, m := range ms {
+			syms = append(syms, m.Symbol)
+		}
+		return syms, nil
+	case "^TAG":
+		t, err := r.Tag(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		is, err := r.InstrumentsFromURLs(ctx, t.Instruments)
+		return symbols(is), err
+	case "^WATCHLIST":
+		ws, err := r.Watchlists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range ws {
+			if strings.EqualFold(w.Name, arg) {
+				is, err := w.Instruments(ctx)
+				return symbols(is), err
+			}
+		}
+		return nil, fmt.Errorf("no watchlist named %q", arg)
+	}
+	return nil, fmt.Errorf("unknown index: %q", name)
+}
+
+// symbols returns the symbols of instruments.
+func symbols(is []roho.Instrument) []string {
+	syms := []string{}
+	for _, i := range is {
+		syms = append(syms, i.Symbol)
+	}
+	return syms
+}
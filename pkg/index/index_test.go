@@ -0,0 +1,41 @@
+package index
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveInvalid(t *testing.T) {
+	tests := []struct {
+		sym  string
+		want string
+	}{
+		{sym: "^TAG", want: "requires an argument"},
+		{sym: "^TAG:", want: "requires an argument"},
+		{sym: "^WATCHLIST:", want: "requires an argument"},
+		{sym: "^SP500:x", want: "takes no argument"},
+		{sym: "^MOVERS_UP:x", want: "takes no argument"},
+		{sym: "^FOO", want: "unknown index"},
+		// Robinhood tokens require a client.
+		{sym: "^MOVERS_DOWN", want: "requires a Robinhood client"},
+		{sym: "^TAG:technology", want: "requires a Robinhood client"},
+	}
+
+	for _, tc := range tests {
+		_, err := Resolve(context.Background(), nil, []string{"AAPL", tc.sym})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Resolve(%q) = %v, want error containing %q", tc.sym, err, tc.want)
+		}
+	}
+}
+
+func TestResolvePlain(t *testing.T) {
+	got, err := Resolve(context.Background(), nil, []string{"AAPL", "MSFT"})
+	if err != nil {
+		t.Fatalf("Resolve() returned error: %v", err)
+	}
+	if strings.Join(got, ",") != "AAPL,MSFT" {
+		t.Errorf("Resolve() = %v, want [AAPL MSFT]", got)
+	}
+}
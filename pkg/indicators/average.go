@@ -0,0 +1,131 @@
+package indicators
+
+import "math"
+
+// SMA is a streaming simple moving average.
+type SMA struct {
+	w   *window
+	sum float64
+}
+
+// NewSMA returns a simple moving average over period values.
+func NewSMA(period int) *SMA {
+	return &SMA{w: newWindow(period)}
+}
+
+// Add adds a value, returning the average once period values have been seen.
+func (s *SMA) Add(v float64) (float64, bool) {
+	s.sum += v
+	if old, ok := s.w.push(v); ok {
+		s.sum -= old
+	}
+	if !s.w.full() {
+		return 0, false
+	}
+	return s.sum / float64(s.w.size), true
+}
+
+// SMASeries returns the simple moving average of a series.
+func SMASeries(fs []float64, period int) []float64 {
+	return series(fs, NewSMA(period).Add)
+}
+
+// EMA is a streaming exponential moving average, seeded with the simple
+// average of its first period values.
+type EMA struct {
+	k     float64
+	seed  *SMA
+	value float64
+	ok    bool
+}
+
+// NewEMA returns an exponential moving average over period values.
+func NewEMA(period int) *EMA {
+	return &EMA{k: 2 / (float64(period) + 1), seed: NewSMA(period)}
+}
+
+// Add adds a value, returning the average once period values have been seen.
+func (e *EMA) Add(v float64) (float64, bool) {
+	if e.ok {
+		e.value = v*e.k + e.value*(1-e.k)
+		return e.value, true
+	}
+	e.value, e.ok = e.seed.Add(v)
+	return e.value, e.ok
+}
+
+// EMASeries returns the exponential moving average of a series.
+func EMASeries(fs []float64, period int) []float64 {
+	return series(fs, NewEMA(period).Add)
+}
+
+// wilder is Wilder's smoothed moving average, as used by RSI and ATR.
+type wilder struct {
+	period float64
+	seed   *SMA
+	value  float64
+	ok     bool
+}
+
+func newWilder(period int) *wilder {
+	return &wilder{period: float64(period), seed: NewSMA(period)}
+}
+
+func (w *wilder) add(v float64) (float64, bool) {
+	if w.ok {
+		w.value = (w.value*(w.period-1) + v) / w.period
+		return w.value, true
+	}
+	w.value, w.ok = w.seed.Add(v)
+	return w.value, w.ok
+}
+
+// MACDValue is a single moving average convergence/divergence reading.
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+// MACD is a streaming moving average convergence/divergence calculator.
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+}
+
+// NewMACD returns a MACD calculator, traditionally with periods of 12, 26 and 9.
+func NewMACD(fast, slow, signal int) *MACD {
+	return &MACD{fast: NewEMA(fast), slow: NewEMA(slow), signal: NewEMA(signal)}
+}
+
+// Add adds a value, returning the MACD once the signal line is available.
+func (m *MACD) Add(v float64) (MACDValue, bool) {
+	f, fok := m.fast.Add(v)
+	s, sok := m.slow.Add(v)
+	if !fok || !sok {
+		return MACDValue{}, false
+	}
+
+	line := f - s
+	sig, ok := m.signal.Add(line)
+	if !ok {
+		return MACDValue{MACD: line}, false
+	}
+	return MACDValue{MACD: line, Signal: sig, Histogram: line - sig}, true
+}
+
+// MACDSeries returns the MACD of a series, with NaN values until the signal
+// line is available.
+func MACDSeries(fs []float64, fast, slow, signal int) []MACDValue {
+	m := NewMACD(fast, slow, signal)
+	out := make([]MACDValue, len(fs))
+	for i, f := range fs {
+		v, ok := m.Add(f)
+		if !ok {
+			v = MACDValue{MACD: math.NaN(), Signal: math.NaN(), Histogram: math.NaN()}
+		}
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+// Package indicators calculates technical indicators over price series.
+//
+// Each indicator is available as a streaming calculator, which is fed one
+// value or candle at a time via Add, and as a batch function that returns a
+// series aligned with its input. Values that cannot be calculated yet, such
+// as the first period-1 values of a moving average, are math.NaN in batch
+// results and reported as not ok by calculators.
+package indicators
+
+import (
+	"math"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// Closes returns the closing prices of a series of candles.
+func Closes(rs []roho.HistoricalRecord) []float64 {
+	fs := make([]float64, len(rs))
+	for i, r := range rs {
+		fs[i] = r.ClosePrice
+	}
+	return fs
+}
+
+// Typical returns the typical price, (high + low + close) / 3, of a candle.
+func Typical(r roho.HistoricalRecord) float64 {
+	return (r.HighPrice + r.LowPrice + r.ClosePrice) / 3
+}
+
+// Last returns the most recent calculated value of a batch series, and
+// whether there was one.
+func Last(fs []float64) (float64, bool) {
+	if len(fs) == 0 || math.IsNaN(fs[len(fs)-1]) {
+		return 0, false
+	}
+	return fs[len(fs)-1], true
+}
+
+// window is a fixed-size sliding window of values.
+type window struct {
+	size int
+	vs   []float64
+}
+
+func newWindow(size int) *window {
+	if size < 1 {
+		size = 1
+	}
+	return &window{size: size, vs: make([]float64, 0, size)}
+}
+
+// push adds a value, returning the value that was evicted, if any.
+func (w *window) push(v float64) (float64, bool) {
+	if len(w.vs) < w.size {
+		w.vs = append(w.vs, v)
+		return 0, false
+	}
+	old := w.vs[0]
+	copy(w.vs, w.vs[1:])
+	w.vs[len(w.vs)-1] = v
+	return old, true
+}
+
+func (w *window) full() bool {
+	return len(w.vs) == w.size
+}
+
+func (w *window) min() float64 {
+	m := math.Inf(1)
+	for _, v := range w.vs {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func (w *window) max() float64 {
+	m := math.Inf(-1)
+	for _, v := range w.vs {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+// series applies a streaming calculator to each value, returning NaN where it
+// is not yet ok.
+func series(fs []float64, add func(float64) (float64, bool)) []float64 {
+	out := make([]float64, len(fs))
+	for i, f := range fs {
+		v, ok := add(f)
+		if !ok {
+			v = math.NaN()
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// candleSeries applies a streaming calculator to each candle, returning NaN
+// where it is not yet ok.
+func candleSeries(rs []roho.HistoricalRecord, add func(roho.HistoricalRecord) (float64, bool)) []float64 {
+	out := make([]float64, len(rs))
+	for i, r := range rs {
+		v, ok := add(r)
+		if !ok {
+			v = math.NaN()
+		}
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+var (
+	nan = math.NaN()
+	// approx considers floats equal if both are NaN, or within 0.001 of each other.
+	approx = cmp.Comparer(func(a, b float64) bool {
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return math.IsNaN(a) && math.IsNaN(b)
+		}
+		return math.Abs(a-b) < 0.001
+	})
+)
+
+func candle(h, l, c float64, v int64) roho.HistoricalRecord {
+	return roho.HistoricalRecord{HighPrice: h, LowPrice: l, ClosePrice: c, Volume: v}
+}
+
+func TestSeries(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"SMA", SMASeries([]float64{2, 4, 6, 8, 3}, 3), []float64{nan, nan, 4, 6, 5.667}},
+		{"EMA", EMASeries([]float64{2, 4, 6, 8, 3}, 3), []float64{nan, nan, 4, 6, 4.5}},
+		{"RSI", RSISeries([]float64{1, 2, 3, 2, 4}, 2), []float64{nan, nan, 100, 50, 83.333}},
+		{"RSI flat", RSISeries([]float64{5, 5, 5}, 2), []float64{nan, nan, 50}},
+		{"ATR", ATRSeries([]roho.HistoricalRecord{candle(10, 8, 9, 0), candle(12, 9, 11, 0), candle(11, 10, 10.5, 0)}, 2), []float64{nan, 2.5, 1.75}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.got, approx); diff != "" {
+				t.Errorf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamingMatchesBatch(t *testing.T) {
+	fs := []float64{44.3, 44.1, 44.2, 43.6, 44.3, 44.8, 45.1, 45.4, 45.8, 46.1, 45.9, 46.3, 45.6, 46.3, 46.3, 46.0, 46.4, 46.2, 45.6, 46.2}
+	batch := RSISeries(fs, 14)
+
+	r := NewRSI(14)
+	for i, f := range fs {
+		v, ok := r.Add(f)
+		if ok != !math.IsNaN(batch[i]) {
+			t.Fatalf("RSI.Add(%d) ok = %v, batch = %v", i, ok, batch[i])
+		}
+		if ok && v != batch[i] {
+			t.Errorf("RSI.Add(%d) = %v, batch = %v", i, v, batch[i])
+		}
+	}
+
+	last, ok := Last(batch)
+	if !ok || last < 0 || last > 100 {
+		t.Errorf("Last() = %v, %v; want value within 0-100", last, ok)
+	}
+}
+
+func TestMACD(t *testing.T) {
+	got := MACDSeries([]float64{1, 2, 3, 4, 5}, 2, 3, 2)
+	n := MACDValue{MACD: nan, Signal: nan, Histogram: nan}
+	want := []MACDValue{n, n, n, {MACD: 0.5, Signal: 0.5}, {MACD: 0.5, Signal: 0.5}}
+	if diff := cmp.Diff(want, got, approx); diff != "" {
+		t.Errorf("MACDSeries() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	got := BollingerSeries([]float64{1, 3, 5}, 2, 1)
+	want := []Band{{Lower: nan, Middle: nan, Upper: nan}, {Lower: 1, Middle: 2, Upper: 3}, {Lower: 3, Middle: 4, Upper: 5}}
+	if diff := cmp.Diff(want, got, approx); diff != "" {
+		t.Errorf("BollingerSeries() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStochastic(t *testing.T) {
+	rs := []roho.HistoricalRecord{candle(10, 8, 9, 0), candle(12, 9, 11, 0), candle(11, 10, 10.5, 0), candle(13, 10, 13, 0)}
+	got := StochasticSeries(rs, 2, 2)
+	n := StochasticValue{K: nan, D: nan}
+	want := []StochasticValue{n, n, {K: 50, D: 62.5}, {K: 100, D: 75}}
+	if diff := cmp.Diff(want, got, approx); diff != "" {
+		t.Errorf("StochasticSeries() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVolume(t *testing.T) {
+	day1 := time.Date(2021, 9, 14, 14, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	rs := []roho.HistoricalRecord{candle(10, 8, 9, 100), candle(11, 9, 10, 300), candle(21, 19, 20, 10), candle(21, 19, 20, 70)}
+	rs[0].BeginsAt, rs[1].BeginsAt, rs[2].BeginsAt, rs[3].BeginsAt = day1, day1.Add(time.Minute), day2, day2.Add(time.Minute)
+
+	if diff := cmp.Diff([]float64{9, 9.75, 20, 20}, VWAPSeries(rs), approx); diff != "" {
+		t.Errorf("VWAPSeries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{0, 300, 310, 310}, OBVSeries(rs)); diff != "" {
+		t.Errorf("OBVSeries() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,100 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// RSI is a streaming relative strength index, using Wilder's smoothing.
+type RSI struct {
+	gain  *wilder
+	loss  *wilder
+	prev  float64
+	first bool
+}
+
+// NewRSI returns a relative strength index over period changes, traditionally 14.
+func NewRSI(period int) *RSI {
+	return &RSI{gain: newWilder(period), loss: newWilder(period), first: true}
+}
+
+// Add adds a value, returning the index (0-100) once period changes have been seen.
+func (r *RSI) Add(v float64) (float64, bool) {
+	if r.first {
+		r.prev, r.first = v, false
+		return 0, false
+	}
+
+	change := v - r.prev
+	r.prev = v
+
+	g, ok := r.gain.add(math.Max(change, 0))
+	l, _ := r.loss.add(math.Max(-change, 0))
+	if !ok {
+		return 0, false
+	}
+	if l == 0 {
+		if g == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+g/l), true
+}
+
+// RSISeries returns the relative strength index of a series.
+func RSISeries(fs []float64, period int) []float64 {
+	return series(fs, NewRSI(period).Add)
+}
+
+// StochasticValue is a single stochastic oscillator reading.
+type StochasticValue struct {
+	K float64
+	D float64
+}
+
+// Stochastic is a streaming stochastic oscillator.
+type Stochastic struct {
+	highs *window
+	lows  *window
+	d     *SMA
+}
+
+// NewStochastic returns a stochastic oscillator where %K covers kPeriod candles
+// and %D is its dPeriod average, traditionally 14 and 3.
+func NewStochastic(kPeriod, dPeriod int) *Stochastic {
+	return &Stochastic{highs: newWindow(kPeriod), lows: newWindow(kPeriod), d: NewSMA(dPeriod)}
+}
+
+// Add adds a candle, returning the oscillator once %D is available.
+func (s *Stochastic) Add(r roho.HistoricalRecord) (StochasticValue, bool) {
+	s.highs.push(r.HighPrice)
+	s.lows.push(r.LowPrice)
+	if !s.highs.full() {
+		return StochasticValue{}, false
+	}
+
+	hi, lo := s.highs.max(), s.lows.min()
+	k := 50.0
+	if hi > lo {
+		k = 100 * (r.ClosePrice - lo) / (hi - lo)
+	}
+
+	d, ok := s.d.Add(k)
+	return StochasticValue{K: k, D: d}, ok
+}
+
+// StochasticSeries returns the stochastic oscillator of a series of candles.
+func StochasticSeries(rs []roho.HistoricalRecord, kPeriod, dPeriod int) []StochasticValue {
+	s := NewStochastic(kPeriod, dPeriod)
+	out := make([]StochasticValue, len(rs))
+	for i, r := range rs {
+		v, ok := s.Add(r)
+		if !ok {
+			v = StochasticValue{K: math.NaN(), D: math.NaN()}
+		}
+		out[i] = v
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// Band is a single Bollinger Bands reading.
+type Band struct {
+	Lower  float64
+	Middle float64
+	Upper  float64
+}
+
+// Width returns the distance between the upper and lower bands, as a
+// percentage of the middle band.
+func (b Band) Width() float64 {
+	return (b.Upper - b.Lower) / b.Middle * 100
+}
+
+// Bollinger is a streaming Bollinger Bands calculator.
+type Bollinger struct {
+	w   *window
+	sma *SMA
+	k   float64
+}
+
+// NewBollinger returns Bollinger Bands k standard deviations around a period
+// moving average, traditionally 20 and 2.
+func NewBollinger(period int, k float64) *Bollinger {
+	return &Bollinger{w: newWindow(period), sma: NewSMA(period), k: k}
+}
+
+// Add adds a value, returning the bands once period values have been seen.
+func (b *Bollinger) Add(v float64) (Band, bool) {
+	b.w.push(v)
+	mean, ok := b.sma.Add(v)
+	if !ok {
+		return Band{}, false
+	}
+
+	variance := 0.0
+	for _, x := range b.w.vs {
+		variance += (x - mean) * (x - mean)
+	}
+	sd := math.Sqrt(variance / float64(len(b.w.vs)))
+
+	return Band{Lower: mean - b.k*sd, Middle: mean, Upper: mean + b.k*sd}, true
+}
+
+// BollingerSeries returns the Bollinger Bands of a series.
+func BollingerSeries(fs []float64, period int, k float64) []Band {
+	b := NewBollinger(period, k)
+	out := make([]Band, len(fs))
+	for i, f := range fs {
+		v, ok := b.Add(f)
+		if !ok {
+			v = Band{Lower: math.NaN(), Middle: math.NaN(), Upper: math.NaN()}
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// ATR is a streaming average true range, using Wilder's smoothing.
+type ATR struct {
+	avg       *wilder
+	prevClose float64
+	first     bool
+}
+
+// NewATR returns an average true range over period candles, traditionally 14.
+func NewATR(period int) *ATR {
+	return &ATR{avg: newWilder(period), first: true}
+}
+
+// Add adds a candle, returning the average once period candles have been seen.
+func (a *ATR) Add(r roho.HistoricalRecord) (float64, bool) {
+	tr := r.HighPrice - r.LowPrice
+	if !a.first {
+		tr = math.Max(tr, math.Max(math.Abs(r.HighPrice-a.prevClose), math.Abs(r.LowPrice-a.prevClose)))
+	}
+	a.prevClose, a.first = r.ClosePrice, false
+	return a.avg.add(tr)
+}
+
+// ATRSeries returns the average true range of a series of candles.
+func ATRSeries(rs []roho.HistoricalRecord, period int) []float64 {
+	return candleSeries(rs, NewATR(period).Add)
+}
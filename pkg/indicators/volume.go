@@ -0,0 +1,87 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// VWAP is a streaming volume weighted average price.
+type VWAP struct {
+	pv  float64
+	vol float64
+}
+
+// NewVWAP returns a volume weighted average price calculator.
+func NewVWAP() *VWAP {
+	return &VWAP{}
+}
+
+// Reset starts a new averaging period, such as a new trading day.
+func (v *VWAP) Reset() {
+	v.pv, v.vol = 0, 0
+}
+
+// Add adds a candle, returning the average once any volume has been seen.
+func (v *VWAP) Add(r roho.HistoricalRecord) (float64, bool) {
+	v.pv += Typical(r) * float64(r.Volume)
+	v.vol += float64(r.Volume)
+	if v.vol == 0 {
+		return 0, false
+	}
+	return v.pv / v.vol, true
+}
+
+// VWAPSeries returns the volume weighted average price of a series of candles,
+// starting afresh each day in New York.
+func VWAPSeries(rs []roho.HistoricalRecord) []float64 {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		loc = time.UTC
+	}
+
+	v := NewVWAP()
+	var day string
+	return candleSeries(rs, func(r roho.HistoricalRecord) (float64, bool) {
+		if d := r.BeginsAt.In(loc).Format("2006-01-02"); d != day {
+			day = d
+			v.Reset()
+		}
+		return v.Add(r)
+	})
+}
+
+// OBV is a streaming on-balance volume calculator.
+type OBV struct {
+	value     int64
+	prevClose float64
+	first     bool
+}
+
+// NewOBV returns an on-balance volume calculator starting at 0.
+func NewOBV() *OBV {
+	return &OBV{first: true}
+}
+
+// Add adds a candle, returning the running on-balance volume.
+func (o *OBV) Add(r roho.HistoricalRecord) int64 {
+	switch {
+	case o.first:
+	case r.ClosePrice > o.prevClose:
+		o.value += r.Volume
+	case r.ClosePrice < o.prevClose:
+		o.value -= r.Volume
+	}
+	o.prevClose, o.first = r.ClosePrice, false
+	return o.value
+}
+
+// OBVSeries returns the on-balance volume of a series of candles.
+func OBVSeries(rs []roho.HistoricalRecord) []int64 {
+	o := NewOBV()
+	out := make([]int64, len(rs))
+	for i, r := range rs {
+		out[i] = o.Add(r)
+	}
+	return out
+}
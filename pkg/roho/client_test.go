@@ -0,0 +1,26 @@
+package roho
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// roundTripFunc serves HTTP requests with a function rather than the network.
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// fakeClient returns a Client whose requests are all served by h.
+func fakeClient(h http.HandlerFunc) *Client {
+	return &Client{
+		Account:       &Account{Meta: Meta{URL: "https://api.robinhood.com/accounts/1/"}},
+		CryptoAccount: &CryptoAccount{ID: "crypto-1"},
+		Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+			w := httptest.NewRecorder()
+			h(w, req)
+			return w.Result()
+		})},
+	}
+}
@@ -0,0 +1,147 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CryptoHolding is a crypto currency position valued at current prices.
+type CryptoHolding struct {
+	Position *CryptoPosition
+	Pair     *CryptoCurrencyPair
+	Quote    *CryptoQuote
+
+	Code        string
+	Quantity    float64
+	AverageCost float64
+	CostBasis   float64
+	MarketValue float64
+	// UnrealizedGain is the market value less the cost basis.
+	UnrealizedGain float64
+	// UnrealizedGainPercent is the unrealized gain as a percentage of the cost basis.
+	UnrealizedGainPercent float64
+}
+
+// CryptoHoldingsReport values all crypto currency positions, alongside the
+// crypto portfolio they should reconcile with.
+type CryptoHoldingsReport struct {
+	Holdings  []CryptoHolding
+	Portfolio CryptoPortfolio
+
+	CostBasis      float64
+	MarketValue    float64
+	UnrealizedGain float64
+	// Discrepancy is the portfolio market value less the total market value of the holdings.
+	Discrepancy float64
+}
+
+// Reconciles returns whether the total market value of the holdings is
+// within a percentage tolerance of the portfolio market value. Holdings are
+// valued at the mark price, so small discrepancies are expected.
+func (r *CryptoHoldingsReport) Reconciles(tolerancePercent float64) bool {
+	if r.Portfolio.MarketValue == 0 {
+		return r.MarketValue == 0
+	}
+	return math.Abs(r.Discrepancy/r.Portfolio.MarketValue*100) <= tolerancePercent
+}
+
+// CryptoHoldings returns a report valuing all crypto currency positions at
+// current prices.
+func (c *Client) CryptoHoldings(ctx context.Context) (*CryptoHoldingsReport, error) {
+	ps, err := c.CryptoPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("crypto positions: %w", err)
+	}
+
+	pairs, err := c.CryptoCurrencyPairs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("crypto currency pairs: %w", err)
+	}
+
+	held := []CryptoCurrencyPair{}
+	for _, p := range ps {
+		if pair := pairForCurrency(pairs, p.Currency.ID); pair != nil {
+			held = append(held, *pair)
+		}
+	}
+
+	qs := []CryptoQuote{}
+	if len(held) > 0 {
+		qs, err = c.CryptoQuotes(ctx, held)
+		if err != nil {
+			return nil, fmt.Errorf("crypto quotes: %w", err)
+		}
+	}
+
+	pf, err := c.CryptoPortfolios(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("crypto portfolio: %w", err)
+	}
+
+	return newCryptoHoldingsReport(ps, pairs, qs, pf)
+}
+
+// pairForCurrency returns the USD pair used to value a crypto currency.
+func pairForCurrency(pairs []CryptoCurrencyPair, currencyID string) *CryptoCurrencyPair {
+	for i, p := range pairs {
+		if p.AssetCurrency.ID == currencyID && p.QuoteCurrency.Code == "USD" {
+			return &pairs[i]
+		}
+	}
+	return nil
+}
+
+// newCryptoHoldingsReport joins crypto positions with their pairs and quotes.
+func newCryptoHoldingsReport(ps []CryptoPosition, pairs []CryptoCurrencyPair, qs []CryptoQuote, pf CryptoPortfolio) (*CryptoHoldingsReport, error) {
+	quotes := map[string]*CryptoQuote{}
+	for i, q := range qs {
+		quotes[q.ID] = &qs[i]
+	}
+
+	r := &CryptoHoldingsReport{Portfolio: pf}
+	for i := range ps {
+		p := &ps[i]
+		if p.Quantity == 0 {
+			continue
+		}
+
+		pair := pairForCurrency(pairs, p.Currency.ID)
+		if pair == nil {
+			return nil, fmt.Errorf("no USD pair for %s", p.Currency.Code)
+		}
+
+		q := quotes[pair.ID]
+		if q == nil {
+			return nil, fmt.Errorf("no quote for %s", pair.Symbol)
+		}
+
+		h := CryptoHolding{
+			Position:    p,
+			Pair:        pair,
+			Quote:       q,
+			Code:        p.Currency.Code,
+			Quantity:    p.Quantity,
+			AverageCost: p.AverageCost(),
+			CostBasis:   p.CostBasis(),
+			MarketValue: p.Quantity * q.Price(),
+		}
+		h.UnrealizedGain = h.MarketValue - h.CostBasis
+		if h.CostBasis != 0 {
+			h.UnrealizedGainPercent = h.UnrealizedGain / h.CostBasis * 100
+		}
+
+		r.Holdings = append(r.Holdings, h)
+		r.CostBasis += h.CostBasis
+		r.MarketValue += h.MarketValue
+		r.UnrealizedGain += h.UnrealizedGain
+	}
+
+	sort.Slice(r.Holdings, func(i, j int) bool {
+		return r.Holdings[i].MarketValue > r.Holdings[j].MarketValue
+	})
+
+	r.Discrepancy = pf.MarketValue - r.MarketValue
+	return r, nil
+}
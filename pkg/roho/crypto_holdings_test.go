@@ -0,0 +1,52 @@
+package roho
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewCryptoHoldingsReport(t *testing.T) {
+	pairs := []CryptoCurrencyPair{
+		{ID: "btc-usd", Symbol: "BTC-USD", AssetCurrency: AssetCurrency{ID: "btc", Code: "BTC"}, QuoteCurrency: QuoteCurrency{Code: "USD"}},
+		{ID: "eth-usd", Symbol: "ETH-USD", AssetCurrency: AssetCurrency{ID: "eth", Code: "ETH"}, QuoteCurrency: QuoteCurrency{Code: "USD"}},
+	}
+	ps := []CryptoPosition{
+		{
+			Quantity: 0.5, Currency: CryptoCurrency{ID: "btc", Code: "BTC"},
+			CostBases: []CryptoCostBasis{{DirectCostBasis: 15000, DirectQuantity: 0.5}},
+		},
+		{
+			Quantity: 2, Currency: CryptoCurrency{ID: "eth", Code: "ETH"},
+			CostBases: []CryptoCostBasis{{DirectCostBasis: 5000, DirectQuantity: 2}},
+		},
+		{Quantity: 0, Currency: CryptoCurrency{ID: "doge", Code: "DOGE"}},
+	}
+	qs := []CryptoQuote{
+		{ID: "btc-usd", MarkPrice: 40000},
+		{ID: "eth-usd", MarkPrice: 2000},
+	}
+
+	r, err := newCryptoHoldingsReport(ps, pairs, qs, CryptoPortfolio{MarketValue: 24010})
+	if err != nil {
+		t.Fatalf("newCryptoHoldingsReport() returned error: %v", err)
+	}
+
+	got := []string{}
+	for _, h := range r.Holdings {
+		got = append(got, fmt.Sprintf("%s qty=%g avg=%.2f value=%.2f gain=%.2f (%.1f%%)", h.Code, h.Quantity, h.AverageCost, h.MarketValue, h.UnrealizedGain, h.UnrealizedGainPercent))
+	}
+	want := []string{
+		"BTC qty=0.5 avg=30000.00 value=20000.00 gain=5000.00 (33.3%)",
+		"ETH qty=2 avg=2500.00 value=4000.00 gain=-1000.00 (-20.0%)",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("holdings = %v, want %v", got, want)
+	}
+
+	if r.MarketValue != 24000 || r.CostBasis != 20000 || r.UnrealizedGain != 4000 {
+		t.Errorf("totals = value %.2f, cost %.2f, gain %.2f; want 24000, 20000, 4000", r.MarketValue, r.CostBasis, r.UnrealizedGain)
+	}
+	if r.Discrepancy != 10 || !r.Reconciles(0.1) || r.Reconciles(0.01) {
+		t.Errorf("discrepancy = %.2f, want 10 reconciling within 0.1%% but not 0.01%%", r.Discrepancy)
+	}
+}
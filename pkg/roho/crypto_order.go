@@ -7,21 +7,29 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
-// CryptoOrder is the payload to create a crypto currency order.
+// ErrCryptoOrderTooSmall indicates that an order is below the minimum order size of a currency pair.
+var ErrCryptoOrderTooSmall = errors.New("crypto order is below the minimum order size")
+
+// CryptoOrder is the payload to create a crypto currency order. Price and
+// Quantity are decimal strings, as the API does not accept exponents.
 type CryptoOrder struct {
-	AccountID      string  `json:"account_id,omitempty"`
-	CurrencyPairID string  `json:"currency_pair_id,omitempty"`
-	Price          float64 `json:"price,omitempty"`
-	RefID          string  `json:"ref_id,omitempty"`
-	Side           string  `json:"side,omitempty"`
-	TimeInForce    string  `json:"time_in_force,omitempty"`
-	Quantity       float64 `json:"quantity,omitempty"`
-	Type           string  `json:"type,omitempty"`
+	AccountID      string `json:"account_id,omitempty"`
+	CurrencyPairID string `json:"currency_pair_id,omitempty"`
+	Price          string `json:"price,omitempty"`
+	RefID          string `json:"ref_id,omitempty"`
+	Side           string `json:"side,omitempty"`
+	TimeInForce    string `json:"time_in_force,omitempty"`
+	Quantity       string `json:"quantity,omitempty"`
+	Type           string `json:"type,omitempty"`
 }
 
 // CryptoOrderOutput holds the response from api.
@@ -48,7 +56,8 @@ type CryptoOrderOutput struct {
 	client *Client
 }
 
-// CryptoOrderOpts encapsulates differences between order types.
+// CryptoOrderOpts encapsulates differences between order types. Exactly one
+// of AmountInDollars or Quantity must be set.
 type CryptoOrderOpts struct {
 	Side            OrderSide
 	Type            OrderType
@@ -60,18 +69,83 @@ type CryptoOrderOpts struct {
 	Stop, Force     bool
 }
 
-// CryptoOrder will actually place the order.
+// OrderQuantity returns the quantity of the asset currency to order, rounded
+// down to the quantity increment of the pair. It returns an error if the order
+// would fall outside of the pair's order size limits.
+func (p CryptoCurrencyPair) OrderQuantity(o CryptoOrderOpts) (float64, error) {
+	var q float64
+	switch {
+	case o.Quantity > 0 && o.AmountInDollars > 0:
+		return 0, fmt.Errorf("only one of quantity or amount in dollars may be provided")
+	case o.Quantity > 0:
+		q = o.Quantity
+	case o.AmountInDollars > 0:
+		if o.Price <= 0 {
+			return 0, fmt.Errorf("a price is required to order by amount in dollars")
+		}
+		q = o.AmountInDollars / o.Price
+	default:
+		return 0, fmt.Errorf("quantity or amount in dollars must be provided")
+	}
+
+	q = roundDown(q, p.MinOrderQuantityIncrement)
+
+	if q <= 0 || q < p.MinOrderSize {
+		return 0, fmt.Errorf("%w: %s quantity %s < %s", ErrCryptoOrderTooSmall, p.Symbol, formatDecimal(q), formatDecimal(p.MinOrderSize))
+	}
+	if p.MaxOrderSize > 0 && q > p.MaxOrderSize {
+		return 0, fmt.Errorf("%s quantity %s exceeds maximum order size of %s", p.Symbol, formatDecimal(q), formatDecimal(p.MaxOrderSize))
+	}
+	return q, nil
+}
+
+// roundDown rounds f down to a multiple of increment.
+func roundDown(f, increment float64) float64 {
+	if increment <= 0 {
+		return f
+	}
+	// The epsilon forgives floating point error, such as 0.3/0.1 = 2.9999999999999996
+	return roundDecimal(math.Floor(f/increment+1e-9) * increment)
+}
+
+// roundNearest rounds f to the nearest multiple of increment.
+func roundNearest(f, increment float64) float64 {
+	if increment <= 0 {
+		return f
+	}
+	return roundDecimal(math.Round(f/increment) * increment)
+}
+
+// roundDecimal discards floating point noise beyond the precision the API supports.
+func roundDecimal(f float64) float64 {
+	return math.Round(f*1e9) / 1e9
+}
+
+// formatDecimal formats a float without exponents.
+func formatDecimal(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// CryptoOrder will actually place the order. The order is validated against
+// the pair's order size limits before it is sent.
 func (c *Client) CryptoOrder(ctx context.Context, cryptoPair CryptoCurrencyPair, o CryptoOrderOpts) (*CryptoOrderOutput, error) {
-	quantity := math.Round(o.AmountInDollars / o.Price)
+	quantity, err := cryptoPair.OrderQuantity(o)
+	if err != nil {
+		return nil, err
+	}
+
 	a := CryptoOrder{
 		AccountID:      c.CryptoAccount.ID,
 		CurrencyPairID: cryptoPair.ID,
-		Quantity:       quantity,
-		Price:          o.Price,
+		Quantity:       formatDecimal(quantity),
 		RefID:          uuid.New().String(),
-		Side:           o.Side.String(),
-		TimeInForce:    o.TimeInForce.String(),
-		Type:           o.Type.String(),
+		Side:           strings.ToLower(o.Side.String()),
+		TimeInForce:    strings.ToLower(o.TimeInForce.String()),
+		Type:           strings.ToLower(o.Type.String()),
+	}
+
+	if o.Price > 0 {
+		a.Price = formatDecimal(roundNearest(o.Price, cryptoPair.MinOrderPriceIncrement))
 	}
 
 	payload, err := json.Marshal(a)
@@ -92,9 +166,51 @@ func (c *Client) CryptoOrder(ctx context.Context, cryptoPair CryptoCurrencyPair,
 	return &out, err
 }
 
+// cryptoOrderURL returns the URL of a crypto order, which the API does not provide.
+func cryptoOrderURL(id string) string {
+	return cryptoURL("orders") + id + "/"
+}
+
+// Update returns any errors and updates the item with any recent changes.
+func (o *CryptoOrderOutput) Update(ctx context.Context) error {
+	return o.client.get(ctx, cryptoOrderURL(o.ID), o)
+}
+
+// Done returns whether the order has reached a final state.
+func (o *CryptoOrderOutput) Done() bool {
+	switch o.State {
+	case "filled", "cancelled", "rejected", "failed":
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait polls the order until it reaches a final state, or the context is
+// cancelled. Cancellation of the context does not cancel the order.
+func (o *CryptoOrderOutput) Wait(ctx context.Context, interval time.Duration) error {
+	for !o.Done() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if err := o.Update(ctx); err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+	}
+	return nil
+}
+
 // Cancel will cancel the order.
 func (o CryptoOrderOutput) Cancel(ctx context.Context) error {
-	post, err := http.NewRequest("POST", o.CancelURL, nil)
+	u := o.CancelURL
+	if u == "" {
+		u = cryptoOrderURL(o.ID) + "cancel/"
+	}
+
+	post, err := http.NewRequestWithContext(ctx, "POST", u, nil)
 	if err != nil {
 		return err
 	}
@@ -112,3 +228,77 @@ func (o CryptoOrderOutput) Cancel(ctx context.Context) error {
 
 	return nil
 }
+
+// CryptoOrdersQuery filters the orders returned by CryptoOrders. Zero values
+// match everything.
+type CryptoOrdersQuery struct {
+	CurrencyPairID string
+	// State is an order state, such as "filled" or "cancelled".
+	State string
+	Side  OrderSide
+	// Since only includes orders updated at or after this time.
+	Since time.Time
+}
+
+func (q CryptoOrdersQuery) encode() string {
+	v := url.Values{}
+	if !q.Since.IsZero() {
+		v.Set("updated_at[gte]", q.Since.UTC().Format(time.RFC3339))
+	}
+	return v.Encode()
+}
+
+// match returns whether an order matches the query.
+func (q CryptoOrdersQuery) match(o CryptoOrderOutput) bool {
+	if q.CurrencyPairID != "" && o.CurrencyPairID != q.CurrencyPairID {
+		return false
+	}
+	if q.State != "" && o.State != q.State {
+		return false
+	}
+	if q.Side != 0 && o.Side != strings.ToLower(q.Side.String()) {
+		return false
+	}
+	if !q.Since.IsZero() && o.UpdatedAt.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+// CryptoOrders returns the crypto orders made by this client that match the
+// query, paging through results until they are exhausted or the context is
+// cancelled.
+func (c *Client) CryptoOrders(ctx context.Context, q CryptoOrdersQuery) ([]CryptoOrderOutput, error) {
+	orders := []CryptoOrderOutput{}
+
+	next := cryptoURL("orders")
+	if e := q.encode(); e != "" {
+		next += "?" + e
+	}
+
+	for next != "" {
+		select {
+		case <-ctx.Done():
+			return orders, ctx.Err()
+		default:
+		}
+
+		var out struct {
+			Results []CryptoOrderOutput
+			Pager
+		}
+		if err := c.get(ctx, next, &out); err != nil {
+			return orders, err
+		}
+
+		for _, o := range out.Results {
+			if q.match(o) {
+				o.client = c
+				orders = append(orders, o)
+			}
+		}
+		next = out.NextURL
+	}
+
+	return orders, nil
+}
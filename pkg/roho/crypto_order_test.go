@@ -0,0 +1,144 @@
+package roho
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var btc = CryptoCurrencyPair{
+	ID:                        "btc-usd",
+	Symbol:                    "BTC-USD",
+	MinOrderSize:              0.000001,
+	MaxOrderSize:              20,
+	MinOrderPriceIncrement:    0.01,
+	MinOrderQuantityIncrement: 0.00000001,
+}
+
+func TestOrderQuantity(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       CryptoOrderOpts
+		want    float64
+		wantErr bool
+	}{
+		{name: "dollars", o: CryptoOrderOpts{AmountInDollars: 50, Price: 40000}, want: 0.00125},
+		{name: "dollars rounded down", o: CryptoOrderOpts{AmountInDollars: 10, Price: 30000}, want: 0.00033333},
+		{name: "quantity", o: CryptoOrderOpts{Quantity: 0.015, Price: 40000}, want: 0.015},
+		{name: "quantity without price", o: CryptoOrderOpts{Quantity: 0.015}, want: 0.015},
+		{name: "below minimum", o: CryptoOrderOpts{Quantity: 0.0000005}, wantErr: true},
+		{name: "above maximum", o: CryptoOrderOpts{Quantity: 25}, wantErr: true},
+		{name: "both", o: CryptoOrderOpts{Quantity: 1, AmountInDollars: 50, Price: 40000}, wantErr: true},
+		{name: "neither", o: CryptoOrderOpts{Price: 40000}, wantErr: true},
+		{name: "dollars without price", o: CryptoOrderOpts{AmountInDollars: 50}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := btc.OrderQuantity(tc.o)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: OrderQuantity(%+v) error = %v, wantErr %v", tc.name, tc.o, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: OrderQuantity(%+v) = %v, want %v", tc.name, tc.o, got, tc.want)
+		}
+	}
+
+	if _, err := btc.OrderQuantity(CryptoOrderOpts{Quantity: 0.0000005}); !errors.Is(err, ErrCryptoOrderTooSmall) {
+		t.Errorf("OrderQuantity() error = %v, want ErrCryptoOrderTooSmall", err)
+	}
+}
+
+func TestCryptoOrder(t *testing.T) {
+	var got CryptoOrder
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		fmt.Fprint(w, `{"id": "order-1", "state": "unconfirmed"}`)
+	})
+
+	out, err := c.CryptoOrder(context.Background(), btc, CryptoOrderOpts{Side: Buy, Type: Limit, AmountInDollars: 50, Price: 40000.004, TimeInForce: GTC})
+	if err != nil {
+		t.Fatalf("CryptoOrder() returned error: %v", err)
+	}
+	if out.ID != "order-1" {
+		t.Errorf("CryptoOrder().ID = %q, want %q", out.ID, "order-1")
+	}
+
+	got.RefID = ""
+	want := CryptoOrder{
+		AccountID:      "crypto-1",
+		CurrencyPairID: "btc-usd",
+		Price:          "40000",
+		Side:           "buy",
+		TimeInForce:    "gtc",
+		Quantity:       "0.00124999",
+		Type:           "limit",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected order diff: %s", diff)
+	}
+}
+
+func TestCryptoOrders(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"next": "https://nummus.robinhood.com/orders/?cursor=2", "results": [
+				{"id": "o1", "currency_pair_id": "btc-usd", "side": "buy", "state": "filled"},
+				{"id": "o2", "currency_pair_id": "eth-usd", "side": "buy", "state": "filled"}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"next": null, "results": [
+			{"id": "o3", "currency_pair_id": "btc-usd", "side": "sell", "state": "filled"},
+			{"id": "o4", "currency_pair_id": "btc-usd", "side": "buy", "state": "cancelled"}
+		]}`)
+	})
+
+	os, err := c.CryptoOrders(context.Background(), CryptoOrdersQuery{CurrencyPairID: "btc-usd", State: "filled"})
+	if err != nil {
+		t.Fatalf("CryptoOrders() returned error: %v", err)
+	}
+
+	got := []string{}
+	for _, o := range os {
+		got = append(got, o.ID)
+	}
+	if diff := cmp.Diff([]string{"o1", "o3"}, got); diff != "" {
+		t.Errorf("unexpected orders diff: %s", diff)
+	}
+}
+
+func TestCryptoOrderWait(t *testing.T) {
+	states := []string{"confirmed", "filled"}
+	calls := 0
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orders/o1/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"id": "o1", "state": %q}`, states[calls])
+		calls++
+	})
+
+	o := &CryptoOrderOutput{ID: "o1", State: "unconfirmed", client: c}
+	if err := o.Wait(context.Background(), time.Millisecond); err != nil {
+		t.Fatalf("Wait() returned error: %v", err)
+	}
+	if o.State != "filled" || calls != 2 {
+		t.Errorf("Wait() left state %q after %d calls, want filled after 2", o.State, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	o = &CryptoOrderOutput{ID: "o1", State: "unconfirmed", client: c}
+	if err := o.Wait(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,81 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CryptoQuote is the current pricing data for a crypto currency pair.
+type CryptoQuote struct {
+	AskPrice  float64 `json:"ask_price,string"`
+	BidPrice  float64 `json:"bid_price,string"`
+	MarkPrice float64 `json:"mark_price,string"`
+	HighPrice float64 `json:"high_price,string"`
+	LowPrice  float64 `json:"low_price,string"`
+	OpenPrice float64 `json:"open_price,string"`
+	Symbol    string  `json:"symbol"`
+	ID        string  `json:"id"`
+	Volume    float64 `json:"volume,string"`
+}
+
+// Price returns the mark price, as crypto currencies trade around the clock.
+func (q CryptoQuote) Price() float64 {
+	return q.MarkPrice
+}
+
+// CryptoQuote returns the latest quote for a crypto currency pair.
+func (c *Client) CryptoQuote(ctx context.Context, pair CryptoCurrencyPair) (CryptoQuote, error) {
+	qs, err := c.CryptoQuotes(ctx, []CryptoCurrencyPair{pair})
+	if err != nil {
+		return CryptoQuote{}, err
+	}
+	if len(qs) == 0 {
+		return CryptoQuote{}, fmt.Errorf("no quote for %q", pair.Symbol)
+	}
+	return qs[0], nil
+}
+
+// CryptoQuotes returns the latest quotes for the crypto currency pairs provided.
+func (c *Client) CryptoQuotes(ctx context.Context, pairs []CryptoCurrencyPair) ([]CryptoQuote, error) {
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("0 pairs provided")
+	}
+
+	ids := make([]string, len(pairs))
+	for i, p := range pairs {
+		ids[i] = p.ID
+	}
+
+	url := baseURL("marketdata/forex/quotes") + "?ids=" + strings.Join(ids, ",")
+	var r struct{ Results []CryptoQuote }
+	err := c.get(ctx, url, &r)
+	return r.Results, err
+}
+
+// CryptoHistoricals returns historical data for a crypto currency pair. See
+// the interval/span constants for hints. bounds defaults to Bounds247.
+func (c *Client) CryptoHistoricals(ctx context.Context, pair CryptoCurrencyPair, interval Interval, span Span, bounds Bounds) (Historical, error) {
+	if bounds == "" {
+		bounds = Bounds247
+	}
+
+	p := HistoricalParams{Interval: interval, Span: span, Bounds: bounds}
+	if err := p.Validate(); err != nil {
+		return Historical{}, err
+	}
+
+	url := baseURL("marketdata/forex/historicals") + pair.ID + "/?" + p.encode().Encode()
+	var r struct {
+		Historical
+		DataPoints []HistoricalRecord `json:"data_points"`
+	}
+	if err := c.get(ctx, url, &r); err != nil {
+		return Historical{}, err
+	}
+
+	h := r.Historical
+	h.Symbol = pair.Symbol
+	h.Records = r.DataPoints
+	return h, nil
+}
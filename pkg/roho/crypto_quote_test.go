@@ -0,0 +1,51 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCryptoQuote(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ids"); got != "btc-usd" {
+			t.Errorf("ids = %q, want %q", got, "btc-usd")
+		}
+		fmt.Fprint(w, `{"results": [{"ask_price": "40010.00", "bid_price": "39990.00", "mark_price": "40000.00", "high_price": "41000.00", "low_price": "39000.00", "symbol": "BTCUSD", "id": "btc-usd", "volume": "12.5"}]}`)
+	})
+
+	q, err := c.CryptoQuote(context.Background(), btc)
+	if err != nil {
+		t.Fatalf("CryptoQuote() returned error: %v", err)
+	}
+	if q.Price() != 40000 || q.Volume != 12.5 {
+		t.Errorf("CryptoQuote() = %+v, want price 40000 and volume 12.5", q)
+	}
+}
+
+func TestCryptoHistoricals(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/marketdata/forex/historicals/btc-usd/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("bounds"); got != "24_7" {
+			t.Errorf("bounds = %q, want %q", got, "24_7")
+		}
+		fmt.Fprint(w, `{"interval": "hour", "span": "day", "bounds": "24_7", "data_points": [
+			{"begins_at": "2021-09-14T00:00:00Z", "open_price": "40000.00", "close_price": "40100.00", "high_price": "40200.00", "low_price": "39900.00", "volume": 0, "session": "reg", "interpolated": false},
+			{"begins_at": "2021-09-14T01:00:00Z", "open_price": "40100.00", "close_price": "40050.00", "high_price": "40150.00", "low_price": "40000.00", "volume": 0, "session": "reg", "interpolated": false}
+		]}`)
+	})
+
+	h, err := c.CryptoHistoricals(context.Background(), btc, Hour, Day, "")
+	if err != nil {
+		t.Fatalf("CryptoHistoricals() returned error: %v", err)
+	}
+	if h.Symbol != "BTC-USD" || h.Interval != Hour || len(h.Records) != 2 {
+		t.Fatalf("CryptoHistoricals() = %+v, want 2 hourly BTC-USD records", h)
+	}
+	if h.Records[1].ClosePrice != 40050 {
+		t.Errorf("Records[1].ClosePrice = %v, want 40050", h.Records[1].ClosePrice)
+	}
+}
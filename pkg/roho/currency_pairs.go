@@ -7,15 +7,16 @@ import (
 )
 
 type CryptoCurrencyPair struct {
-	AssetCurrency          AssetCurrency `json:"asset_currency"`
-	ID                     string        `json:"id"`
-	MaxOrderSize           float64       `json:"max_order_size,string"`
-	MinOrderPriceIncrement float64       `json:"min_order_price_increment,string"`
-	MinOrderSize           float64       `json:"min_order_size,string"`
-	Name                   string        `json:"name"`
-	QuoteCurrency          QuoteCurrency `json:"quote_currency"`
-	Symbol                 string        `json:"symbol"`
-	Tradability            string        `json:"tradability"`
+	AssetCurrency             AssetCurrency `json:"asset_currency"`
+	ID                        string        `json:"id"`
+	MaxOrderSize              float64       `json:"max_order_size,string"`
+	MinOrderPriceIncrement    float64       `json:"min_order_price_increment,string"`
+	MinOrderQuantityIncrement float64       `json:"min_order_quantity_increment,string"`
+	MinOrderSize              float64       `json:"min_order_size,string"`
+	Name                      string        `json:"name"`
+	QuoteCurrency             QuoteCurrency `json:"quote_currency"`
+	Symbol                    string        `json:"symbol"`
+	Tradability               string        `json:"tradability"`
 }
 
 // QuoteCurrency holds info about currency you can use to buy the cyrpto currency.
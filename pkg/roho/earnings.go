@@ -0,0 +1,152 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+// Timing of an earnings report relative to the trading day.
+const (
+	BeforeOpen = "am"
+	AfterClose = "pm"
+)
+
+// Earnings is a quarterly earnings report, past or upcoming.
+type Earnings struct {
+	Symbol     string          `json:"symbol"`
+	Instrument string          `json:"instrument"`
+	Year       int             `json:"year"`
+	Quarter    int             `json:"quarter"`
+	EPS        EarningsEPS     `json:"eps"`
+	Report     *EarningsReport `json:"report"`
+	Call       *EarningsCall   `json:"call"`
+}
+
+// EarningsEPS is the earnings per share of a report. Actual is nil until the
+// report is released, and Estimate is nil if there are no estimates.
+type EarningsEPS struct {
+	Estimate *float64 `json:"estimate,string"`
+	Actual   *float64 `json:"actual,string"`
+}
+
+// Surprise returns how much the actual EPS beat (or missed) the estimate by,
+// as a percentage of the estimate, and whether both were available.
+func (e EarningsEPS) Surprise() (float64, bool) {
+	if e.Estimate == nil || e.Actual == nil || *e.Estimate == 0 {
+		return 0, false
+	}
+	return (*e.Actual - *e.Estimate) / abs(*e.Estimate) * 100, true
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// EarningsReport is when earnings are reported.
+type EarningsReport struct {
+	Date Date `json:"date"`
+	// Timing is BeforeOpen or AfterClose.
+	Timing   string `json:"timing"`
+	Verified bool   `json:"verified"`
+}
+
+// EarningsCall is the conference call accompanying an earnings report.
+type EarningsCall struct {
+	Datetime     time.Time `json:"datetime"`
+	BroadcastURL string    `json:"broadcast_url"`
+	ReplayURL    string    `json:"replay_url"`
+}
+
+// earningsConcurrency is the number of symbols whose earnings are fetched at once.
+const earningsConcurrency = 4
+
+// Earnings returns the past and upcoming earnings reports of each symbol,
+// keyed by upper-case symbol, and sorted by report date.
+func (c *Client) Earnings(ctx context.Context, symbols []string) (map[string][]Earnings, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, earningsConcurrency)
+
+	out := map[string][]Earnings{}
+	var firstErr error
+
+	for _, s := range symbols {
+		s := strings.ToUpper(s)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			es, err := c.symbolEarnings(ctx, s)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("earnings %q: %w", s, err)
+				}
+				return
+			}
+			out[s] = es
+		}()
+	}
+
+	wg.Wait()
+	return out, firstErr
+}
+
+// symbolEarnings fetches the earnings reports of a single symbol.
+func (c *Client) symbolEarnings(ctx context.Context, symbol string) ([]Earnings, error) {
+	q := url.Values{"symbol": []string{symbol}}
+	var r struct{ Results []Earnings }
+	if err := c.get(ctx, baseURL("marketdata/earnings")+"?"+q.Encode(), &r); err != nil {
+		return nil, err
+	}
+
+	es := []Earnings{}
+	for _, e := range r.Results {
+		if e.Report != nil {
+			es = append(es, e)
+		}
+	}
+	sort.Slice(es, func(i, j int) bool { return es[i].Report.Date.Before(es[j].Report.Date.Time) })
+	return es, nil
+}
+
+// NextEarnings returns the first earnings report on or after the day of now.
+func NextEarnings(es []Earnings, now time.Time) (Earnings, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, e := range es {
+		if e.Report != nil && !e.Report.Date.Before(today) {
+			return e, true
+		}
+	}
+	return Earnings{}, false
+}
+
+// ReportsWithin returns whether earnings are reported within the next n
+// trading days, counting the day of now as the first. Market holidays are
+// only skipped if pkg/times has an HoursSource. This is useful for avoiding
+// holding positions through earnings.
+func ReportsWithin(es []Earnings, now time.Time, n int) bool {
+	e, ok := NextEarnings(es, now)
+	if !ok || n < 1 {
+		return false
+	}
+
+	// Counting from yesterday makes today the first trading day, or the next trading day if today is not one.
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	return !e.Report.Date.After(times.AddTradingDays(yesterday, n))
+}
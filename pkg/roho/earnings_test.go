@@ -0,0 +1,98 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+func TestEarnings(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/marketdata/earnings/" || r.URL.Query().Get("symbol") != "AAPL" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"results": [
+			{"symbol": "AAPL", "year": 2021, "quarter": 4, "eps": {"estimate": "1.24", "actual": null},
+			 "report": {"date": "2021-10-28", "timing": "pm", "verified": true},
+			 "call": {"datetime": "2021-10-28T21:00:00Z", "broadcast_url": "https://example.com/live", "replay_url": null}},
+			{"symbol": "AAPL", "year": 2021, "quarter": 3, "eps": {"estimate": "1.00", "actual": "1.30"},
+			 "report": {"date": "2021-07-27", "timing": "pm", "verified": true}, "call": null},
+			{"symbol": "AAPL", "year": 2022, "quarter": 1, "eps": {"estimate": null, "actual": null}, "report": null, "call": null}
+		]}`)
+	})
+
+	m, err := c.Earnings(context.Background(), []string{"aapl"})
+	if err != nil {
+		t.Fatalf("Earnings() returned error: %v", err)
+	}
+
+	es := m["AAPL"]
+	if len(es) != 2 {
+		t.Fatalf("Earnings() returned %d reports, want 2: %+v", len(es), es)
+	}
+	if es[0].Quarter != 3 || es[1].Report.Timing != AfterClose || es[1].Call.BroadcastURL != "https://example.com/live" {
+		t.Errorf("Earnings() = %+v", es)
+	}
+
+	if s, ok := es[0].EPS.Surprise(); !ok || s < 29.99 || s > 30.01 {
+		t.Errorf("Surprise() = %v, %v; want 30, true", s, ok)
+	}
+	if _, ok := es[1].EPS.Surprise(); ok {
+		t.Errorf("Surprise() of unreported earnings returned ok")
+	}
+
+	tests := []struct {
+		now  time.Time
+		n    int
+		want bool
+	}{
+		// Monday: the Thursday report is the 4th trading day.
+		{time.Date(2021, 10, 25, 15, 0, 0, 0, time.UTC), 3, false},
+		{time.Date(2021, 10, 25, 15, 0, 0, 0, time.UTC), 4, true},
+		// Saturday: the following Thursday is the 4th trading day.
+		{time.Date(2021, 10, 23, 15, 0, 0, 0, time.UTC), 4, true},
+		{time.Date(2021, 10, 23, 15, 0, 0, 0, time.UTC), 3, false},
+		// The day of the report.
+		{time.Date(2021, 10, 28, 15, 0, 0, 0, time.UTC), 1, true},
+		// After the last known report.
+		{time.Date(2021, 10, 29, 15, 0, 0, 0, time.UTC), 30, false},
+	}
+	for _, tc := range tests {
+		if got := ReportsWithin(es, tc.now, tc.n); got != tc.want {
+			t.Errorf("ReportsWithin(%s, %d) = %v, want %v", tc.now.Format("Mon 2006-01-02"), tc.n, got, tc.want)
+		}
+	}
+}
+
+// holidays is a times.HoursSource closed on weekends and the listed dates.
+type holidays map[string]bool
+
+func (h holidays) Hours(date time.Time) (times.Hours, error) {
+	hs := times.DefaultHours(date)
+	if h[hs.Date.Format("2006-01-02")] {
+		return times.Hours{Date: hs.Date}, nil
+	}
+	return hs, nil
+}
+
+func TestReportsWithinHoliday(t *testing.T) {
+	es := []Earnings{{Symbol: "XYZ", Report: &EarningsReport{Date: NewZonedDate(2021, 11, 29, time.UTC)}}}
+	// The Wednesday before Thanksgiving: Monday is the 4th weekday, but the 3rd trading day.
+	now := time.Date(2021, 11, 24, 15, 0, 0, 0, time.UTC)
+
+	if ReportsWithin(es, now, 3) {
+		t.Errorf("ReportsWithin(3) = true without a holiday calendar")
+	}
+
+	times.SetHoursSource(holidays{"2021-11-25": true})
+	defer times.SetHoursSource(nil)
+	if !ReportsWithin(es, now, 3) {
+		t.Errorf("ReportsWithin(3) = false over Thanksgiving")
+	}
+}
@@ -7,37 +7,104 @@ import (
 
 // Fundamental represents the JSON struct returned by the Robinhood fundamentals API.
 type Fundamental struct {
-	Open          float64 `json:"open,string"`
-	High          float64 `json:"high,string"`
-	Low           float64 `json:"low,string"`
-	Volume        float64 `json:"volume,string"`
-	AverageVolume float64 `json:"average_volume,string"`
-	High52Weeks   float64 `json:"high_52_weeks,string"`
-	DividendYield float64 `json:"dividend_yield,string"`
-	Low52Weeks    float64 `json:"low_52_weeks,string"`
-	MarketCap     float64 `json:"market_cap,string"`
-	PERatio       float64 `json:"pe_ratio,string"`
-	Description   string  `json:"description"`
-	InstrumentURL string  `json:"instrument"`
+	Symbol            string  `json:"symbol"`
+	Open              float64 `json:"open,string"`
+	High              float64 `json:"high,string"`
+	Low               float64 `json:"low,string"`
+	Volume            float64 `json:"volume,string"`
+	AverageVolume     float64 `json:"average_volume,string"`
+	High52Weeks       float64 `json:"high_52_weeks,string"`
+	DividendYield     float64 `json:"dividend_yield,string"`
+	Low52Weeks        float64 `json:"low_52_weeks,string"`
+	MarketCap         float64 `json:"market_cap,string"`
+	PERatio           float64 `json:"pe_ratio,string"`
+	PBRatio           float64 `json:"pb_ratio,string"`
+	SharesOutstanding float64 `json:"shares_outstanding,string"`
+	Float             float64 `json:"float,string"`
+	Sector            string  `json:"sector"`
+	Industry          string  `json:"industry"`
+	CEO               string  `json:"ceo"`
+	HeadquartersCity  string  `json:"headquarters_city"`
+	HeadquartersState string  `json:"headquarters_state"`
+	NumEmployees      int     `json:"num_employees"`
+	YearFounded       int     `json:"year_founded"`
+	Description       string  `json:"description"`
+	InstrumentURL     string  `json:"instrument"`
 }
 
-// Fundamentals returns fundamental data for the list of stock symbols provided.
+// Headquarters returns the city and state the company is headquartered in.
+func (f Fundamental) Headquarters() string {
+	switch {
+	case f.HeadquartersCity == "":
+		return f.HeadquartersState
+	case f.HeadquartersState == "":
+		return f.HeadquartersCity
+	default:
+		return f.HeadquartersCity + ", " + f.HeadquartersState
+	}
+}
+
+// maxFundamentalSymbols is the number of symbols the fundamentals API accepts at once.
+const maxFundamentalSymbols = 100
+
+// Fundamentals returns fundamental data for the list of stock symbols
+// provided. Unknown symbols are omitted.
 func (c *Client) Fundamentals(ctx context.Context, syms ...string) ([]Fundamental, error) {
 	fs := []Fundamental{}
+	for _, ck := range chunkStrings(syms, maxFundamentalSymbols) {
+		r, err := c.fundamentalsChunk(ctx, ck)
+		if err != nil {
+			return fs, err
+		}
+		for _, f := range r {
+			if f != nil {
+				fs = append(fs, *f)
+			}
+		}
+	}
 
-	// Robinhood Fundamentals API only allows 100 symbols at a time
-	for _, ck := range chunkStrings(syms, 100) {
-		url := baseURL("fundamentals") + "?symbols=" + strings.Join(ck, ",")
-		var r struct{ Results []Fundamental }
-		if err := c.get(ctx, url, &r); err != nil {
+	return fs, nil
+}
+
+// FundamentalsBySymbol returns fundamental data for the list of stock symbols
+// provided, keyed by upper-case symbol. Unknown symbols are omitted.
+func (c *Client) FundamentalsBySymbol(ctx context.Context, syms ...string) (map[string]Fundamental, error) {
+	fs := map[string]Fundamental{}
+	for _, ck := range chunkStrings(syms, maxFundamentalSymbols) {
+		r, err := c.fundamentalsChunk(ctx, ck)
+		if err != nil {
 			return fs, err
 		}
-		fs = append(fs, r.Results...)
+
+		for i, f := range r {
+			if f == nil {
+				continue
+			}
+			sym := f.Symbol
+			// Results are returned in the order requested, should the symbol be missing.
+			if sym == "" && len(r) == len(ck) {
+				sym = ck[i]
+			}
+			if sym != "" {
+				fs[strings.ToUpper(sym)] = *f
+			}
+		}
 	}
 
 	return fs, nil
 }
 
+// fundamentalsChunk fetches fundamental data for a single batch of symbols.
+// Unknown symbols have nil results.
+func (c *Client) fundamentalsChunk(ctx context.Context, syms []string) ([]*Fundamental, error) {
+	url := baseURL("fundamentals") + "?symbols=" + strings.Join(syms, ",")
+	var r struct{ Results []*Fundamental }
+	if err := c.get(ctx, url, &r); err != nil {
+		return nil, err
+	}
+	return r.Results, nil
+}
+
 // chunkStrings divides a slice of strings into chunks of a specified size.
 func chunkStrings(input []string, size int) [][]string {
 	var chunks [][]string
@@ -0,0 +1,49 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestFundamentalsBySymbol(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fundamentals/" || r.URL.Query().Get("symbols") != "aapl,NOPE,msft" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"results": [
+			{"symbol": "AAPL", "market_cap": "2500000000000.00", "pe_ratio": "28.50", "pb_ratio": "40.10", "sector": "Electronic Technology",
+			 "industry": "Telecommunications Equipment", "shares_outstanding": "16530000000.00", "float": "16500000000.00", "ceo": "Tim Cook",
+			 "headquarters_city": "Cupertino", "headquarters_state": "California", "num_employees": 147000, "year_founded": 1976},
+			null,
+			{"symbol": "MSFT", "market_cap": "2300000000000.00", "sector": "Technology Services", "num_employees": null}
+		]}`)
+	})
+
+	fs, err := c.FundamentalsBySymbol(context.Background(), "aapl", "NOPE", "msft")
+	if err != nil {
+		t.Fatalf("FundamentalsBySymbol() returned error: %v", err)
+	}
+	if len(fs) != 2 {
+		t.Fatalf("FundamentalsBySymbol() returned %d results, want 2: %+v", len(fs), fs)
+	}
+
+	a := fs["AAPL"]
+	if a.Sector != "Electronic Technology" || a.PBRatio != 40.1 || a.SharesOutstanding != 16530000000 || a.NumEmployees != 147000 || a.YearFounded != 1976 {
+		t.Errorf("AAPL = %+v", a)
+	}
+	if got := a.Headquarters(); got != "Cupertino, California" {
+		t.Errorf("Headquarters() = %q", got)
+	}
+	if fs["MSFT"].Sector != "Technology Services" {
+		t.Errorf("MSFT = %+v", fs["MSFT"])
+	}
+
+	list, err := c.Fundamentals(context.Background(), "aapl", "NOPE", "msft")
+	if err != nil || len(list) != 2 {
+		t.Errorf("Fundamentals() = %d results, %v; want 2, nil", len(list), err)
+	}
+}
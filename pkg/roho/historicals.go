@@ -2,16 +2,18 @@ package roho
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
 
 type Historical struct {
 	Symbol   string             `json:"symbol"`
-	Interval string             `json:"interval"`
-	Bounds   string             `json:"bounds"`
-	Span     string             `json:"span"`
+	Interval Interval           `json:"interval"`
+	Bounds   Bounds             `json:"bounds"`
+	Span     Span               `json:"span"`
 	Records  []HistoricalRecord `json:"historicals"`
 }
 
@@ -26,36 +28,166 @@ type HistoricalRecord struct {
 	Interpolated bool      `json:"interpolated"`
 }
 
+// Interval is the duration of each HistoricalRecord.
+type Interval string
+
+// Span is the duration of time covered by a Historical.
+type Span string
+
+// Bounds is the trading session covered by a Historical.
+type Bounds string
+
 const (
 	// Valid interval values.
-	FiveMinute   = "5minute"
-	TenMinute    = "10minute"
-	ThirtyMinute = "30minute"
-	Hour         = "hour"
+	FiveMinute   Interval = "5minute"
+	TenMinute    Interval = "10minute"
+	ThirtyMinute Interval = "30minute"
+	Hour         Interval = "hour"
 
 	// Valid for both intervals and spans.
 	Day  = "day"
 	Week = "week"
 
 	// Valid span values.
-	Month    = "month"
-	Year     = "year"
-	FiveYear = "5year"
+	Month      Span = "month"
+	ThreeMonth Span = "3month"
+	Year       Span = "year"
+	FiveYear   Span = "5year"
+
+	// Valid bounds values. The default is regular trading hours.
+	BoundsRegular  Bounds = "regular"
+	BoundsExtended Bounds = "extended"
+	BoundsTrading  Bounds = "trading"
+	Bounds247      Bounds = "24_7"
 )
 
-// Historicals returns historical data for the list of stocks provided. See the interval/span constants for hints.
-func (c *Client) Historicals(ctx context.Context, interval string, span string, symbols []string) ([]Historical, error) {
-	url := fmt.Sprintf("%s?interval=%s&span=%s&symbols=%s", baseURL("quotes/historicals"), interval, span, strings.Join(symbols, ","))
-	var r struct{ Results []Historical }
-	err := c.get(ctx, url, &r)
-	return r.Results, err
+// historicalSpans is the set of spans each interval may be requested over.
+var historicalSpans = map[Interval][]Span{
+	FiveMinute:   {Day, Week},
+	TenMinute:    {Day, Week},
+	ThirtyMinute: {Day, Week, Month},
+	Hour:         {Day, Week, Month, ThreeMonth},
+	Day:          {Week, Month, ThreeMonth, Year, FiveYear},
+	Week:         {Month, ThreeMonth, Year, FiveYear},
+}
+
+// SpansFor returns the spans an interval may be requested over, shortest first.
+func SpansFor(i Interval) []Span {
+	return append([]Span{}, historicalSpans[i]...)
+}
+
+// spanDurations are how far back each span reaches, at most.
+var spanDurations = map[Span]time.Duration{
+	Day:        24 * time.Hour,
+	Week:       7 * 24 * time.Hour,
+	Month:      31 * 24 * time.Hour,
+	ThreeMonth: 92 * 24 * time.Hour,
+	Year:       366 * 24 * time.Hour,
+	FiveYear:   5 * 366 * 24 * time.Hour,
+}
+
+// Duration returns how far back the span reaches, at most, or 0 if unknown.
+func (s Span) Duration() time.Duration {
+	return spanDurations[s]
+}
+
+// maxHistoricalSymbols is the number of symbols the historicals API accepts at once.
+const maxHistoricalSymbols = 75
+
+// ErrInvalidHistorical indicates an unsupported interval, span and bounds combination.
+var ErrInvalidHistorical = errors.New("invalid historicals request")
+
+// HistoricalParams encapsulates parameters known to the RobinHood historicals
+// API endpoint.
+type HistoricalParams struct {
+	Interval Interval
+	Span     Span
+	// Bounds defaults to regular trading hours.
+	Bounds Bounds
+}
+
+// Validate returns an error wrapping ErrInvalidHistorical if the API would
+// reject the combination of parameters.
+func (p HistoricalParams) Validate() error {
+	spans, ok := historicalSpans[p.Interval]
+	if !ok {
+		return fmt.Errorf("%w: unknown interval %q", ErrInvalidHistorical, p.Interval)
+	}
+
+	found := false
+	for _, s := range spans {
+		if s == p.Span {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: %q interval is not available over a %q span (valid spans: %v)", ErrInvalidHistorical, p.Interval, p.Span, spans)
+	}
+
+	switch p.Bounds {
+	case "", BoundsRegular, Bounds247:
+	case BoundsExtended, BoundsTrading:
+		if p.Span != Day {
+			return fmt.Errorf("%w: %q bounds are only available over a %q span", ErrInvalidHistorical, p.Bounds, Day)
+		}
+	default:
+		return fmt.Errorf("%w: unknown bounds %q", ErrInvalidHistorical, p.Bounds)
+	}
+	return nil
+}
+
+// encode returns the query string associated with the requested parameters.
+func (p HistoricalParams) encode() url.Values {
+	v := url.Values{}
+	v.Set("interval", string(p.Interval))
+	v.Set("span", string(p.Span))
+	if p.Bounds != "" {
+		v.Set("bounds", string(p.Bounds))
+	}
+	return v
 }
 
 // Historicals returns historical data for the list of stocks provided. See the interval/span constants for hints.
-func (c *Client) Historical(ctx context.Context, interval string, span string, symbol string) (Historical, error) {
+func (c *Client) Historicals(ctx context.Context, interval Interval, span Span, symbols []string) ([]Historical, error) {
+	return c.HistoricalsParams(ctx, HistoricalParams{Interval: interval, Span: span}, symbols)
+}
+
+// HistoricalsParams returns historical data for the list of stocks provided,
+// requesting them in as many chunks as necessary.
+func (c *Client) HistoricalsParams(ctx context.Context, p HistoricalParams, symbols []string) ([]Historical, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("0 symbols provided")
+	}
+
+	hs := []Historical{}
+	for _, ck := range chunkStrings(symbols, maxHistoricalSymbols) {
+		v := p.encode()
+		v.Set("symbols", strings.Join(ck, ","))
+
+		var r struct{ Results []Historical }
+		if err := c.get(ctx, baseURL("quotes/historicals")+"?"+v.Encode(), &r); err != nil {
+			return hs, err
+		}
+		hs = append(hs, r.Results...)
+	}
+	return hs, nil
+}
+
+// Historical returns historical data for a single stock. See the interval/span constants for hints.
+func (c *Client) Historical(ctx context.Context, interval Interval, span Span, symbol string) (Historical, error) {
 	hs, err := c.Historicals(ctx, interval, span, []string{symbol})
 	if err != nil {
 		return Historical{}, err
 	}
-	return hs[0], nil
+
+	for _, h := range hs {
+		if strings.EqualFold(h.Symbol, symbol) {
+			return h, nil
+		}
+	}
+	return Historical{}, fmt.Errorf("no historicals for %q", symbol)
 }
@@ -0,0 +1,83 @@
+package roho
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHistoricalParamsValidate(t *testing.T) {
+	tests := []struct {
+		p     HistoricalParams
+		valid bool
+	}{
+		{p: HistoricalParams{Interval: FiveMinute, Span: Day}, valid: true},
+		{p: HistoricalParams{Interval: FiveMinute, Span: Day, Bounds: BoundsExtended}, valid: true},
+		{p: HistoricalParams{Interval: Day, Span: FiveYear}, valid: true},
+		{p: HistoricalParams{Interval: Hour, Span: ThreeMonth, Bounds: BoundsRegular}, valid: true},
+		{p: HistoricalParams{Interval: FiveMinute, Span: FiveYear}},
+		{p: HistoricalParams{Interval: Hour, Span: Week, Bounds: BoundsTrading}},
+		{p: HistoricalParams{Interval: "minute", Span: Day}},
+		{p: HistoricalParams{Interval: Day, Span: Year, Bounds: "overnight"}},
+	}
+
+	for _, tc := range tests {
+		err := tc.p.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("Validate(%+v) = %v, want valid=%v", tc.p, err, tc.valid)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidHistorical) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidHistorical", tc.p, err)
+		}
+	}
+}
+
+func TestHistoricals(t *testing.T) {
+	calls := 0
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.URL.Query().Get("bounds"); got != "extended" {
+			t.Errorf("bounds = %q, want extended", got)
+		}
+		rs := []Historical{}
+		for _, s := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+			if s != "MISSING" {
+				rs = append(rs, Historical{Symbol: s})
+			}
+		}
+		json.NewEncoder(w).Encode(struct {
+			Results []Historical `json:"results"`
+		}{rs})
+	})
+
+	syms := []string{}
+	for i := 0; i < 80; i++ {
+		syms = append(syms, fmt.Sprintf("S%d", i))
+	}
+
+	hs, err := c.HistoricalsParams(context.Background(), HistoricalParams{Interval: FiveMinute, Span: Day, Bounds: BoundsExtended}, syms)
+	if err != nil {
+		t.Fatalf("HistoricalsParams() returned error: %v", err)
+	}
+	if len(hs) != 80 || calls != 2 {
+		t.Errorf("HistoricalsParams() returned %d results in %d calls, want 80 in 2", len(hs), calls)
+	}
+
+	if _, err := c.HistoricalsParams(context.Background(), HistoricalParams{Interval: FiveMinute, Span: Year}, syms); !errors.Is(err, ErrInvalidHistorical) {
+		t.Errorf("HistoricalsParams() error = %v, want ErrInvalidHistorical", err)
+	}
+}
+
+func TestHistoricalMissing(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"results": []}`)
+	})
+
+	if _, err := c.Historical(context.Background(), FiveMinute, Day, "MISSING"); err == nil {
+		t.Errorf("Historical() returned nil error for a missing symbol")
+	}
+}
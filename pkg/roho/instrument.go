@@ -2,7 +2,12 @@ package roho
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"sync"
+
+	"k8s.io/klog/v2"
 )
 
 // Instrument is a type to represent the "instrument" API type in the
@@ -34,8 +39,37 @@ type Instrument struct {
 	URL                   string      `json:"url"`
 }
 
+// instrumentConcurrency is the number of instruments fetched at once.
+const instrumentConcurrency = 8
+
+// cache adds instruments to the client's instrument cache, if it has one.
+func (c *Client) cache(is ...Instrument) {
+	if c.InstrumentCache == nil {
+		return
+	}
+	if err := c.InstrumentCache.Put(is...); err != nil {
+		klog.Warningf("unable to save instrument cache: %v", err)
+	}
+}
+
 // Instruments returns an Instrument for a single stock symbol.
 func (c *Client) Instrument(ctx context.Context, symbol string) (Instrument, error) {
+	if c.InstrumentCache != nil {
+		if i, ok := c.InstrumentCache.Symbol(symbol); ok {
+			return i, nil
+		}
+	}
+
+	i, err := c.fetchInstrument(ctx, symbol)
+	if err != nil {
+		return i, err
+	}
+	c.cache(i)
+	return i, nil
+}
+
+// fetchInstrument fetches the Instrument for a stock symbol, bypassing the cache.
+func (c *Client) fetchInstrument(ctx context.Context, symbol string) (Instrument, error) {
 	var i struct {
 		Results []Instrument
 	}
@@ -52,27 +86,143 @@ func (c *Client) Instrument(ctx context.Context, symbol string) (Instrument, err
 	return i.Results[0], err
 }
 
-// Instrument returns Instruments for a set of stock symbols.
+// Instrument returns Instruments for a set of stock symbols, in the same
+// order. Instruments which are not cached are fetched concurrently.
 func (c *Client) Instruments(ctx context.Context, syms []string) ([]Instrument, error) {
-	is := []Instrument{}
 	// Unlike quotes, RH has no native way to query for multiple symbols :(
-	for _, s := range syms {
-		i, err := c.Instrument(ctx, s)
-		if err != nil {
-			return is, fmt.Errorf("instrument %q: %w", s, err)
+	return c.lookupInstruments(ctx, syms, func(s string) (Instrument, bool) {
+		if c.InstrumentCache == nil {
+			return Instrument{}, false
 		}
-		is = append(is, i)
-	}
-	return is, nil
+		return c.InstrumentCache.Symbol(s)
+	}, c.fetchInstrument)
 }
 
 // Instrument returns an Instrument given a URL.
 func (c *Client) InstrumentFromURL(ctx context.Context, url string) (Instrument, error) {
-	var i Instrument
+	if c.InstrumentCache != nil {
+		if i, ok := c.InstrumentCache.URL(url); ok {
+			return i, nil
+		}
+	}
 
+	i, err := c.fetchInstrumentURL(ctx, url)
+	if err != nil {
+		return i, err
+	}
+	c.cache(i)
+	return i, nil
+}
+
+// fetchInstrumentURL fetches the Instrument at a URL, bypassing the cache.
+func (c *Client) fetchInstrumentURL(ctx context.Context, url string) (Instrument, error) {
+	var i Instrument
 	if err := c.get(ctx, url, &i); err != nil {
 		return Instrument{}, err
 	}
-
 	return i, nil
 }
+
+// InstrumentsFromURLs returns Instruments for a set of instrument URLs, in the
+// same order. Instruments which are not cached are fetched concurrently.
+func (c *Client) InstrumentsFromURLs(ctx context.Context, urls []string) ([]Instrument, error) {
+	return c.lookupInstruments(ctx, urls, func(u string) (Instrument, bool) {
+		if c.InstrumentCache == nil {
+			return Instrument{}, false
+		}
+		return c.InstrumentCache.URL(u)
+	}, c.fetchInstrumentURL)
+}
+
+// lookupInstruments returns the instruments for a set of keys, such as
+// symbols, from the cache where possible, and otherwise by fetching them
+// concurrently.
+func (c *Client) lookupInstruments(ctx context.Context, keys []string, cached func(string) (Instrument, bool), fetch func(context.Context, string) (Instrument, error)) ([]Instrument, error) {
+	is := make([]Instrument, len(keys))
+	errs := make([]error, len(keys))
+	fetched := []Instrument{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, instrumentConcurrency)
+
+	for n, k := range keys {
+		if i, ok := cached(k); ok {
+			is[n] = i
+			continue
+		}
+
+		n, k := n, k
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			i, err := fetch(ctx, k)
+			if err != nil {
+				errs[n] = fmt.Errorf("instrument %q: %w", k, err)
+				return
+			}
+			is[n] = i
+
+			mu.Lock()
+			fetched = append(fetched, i)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	c.cache(fetched...)
+
+	for n, err := range errs {
+		if err != nil {
+			return is[:n], err
+		}
+	}
+	return is, nil
+}
+
+// ErrNotTradable is returned by CheckTradable if an instrument may not be traded.
+var ErrNotTradable = errors.New("instrument is not tradable")
+
+// CheckTradable fetches the current state of an instrument, bypassing the
+// cache, and returns an error wrapping ErrNotTradable if it may not be traded.
+// As tradability may change while an instrument is cached, call this before
+// placing orders. The cache is refreshed with the result.
+func (c *Client) CheckTradable(ctx context.Context, i *Instrument) error {
+	fresh, err := c.fetchInstrumentURL(ctx, i.URL)
+	if err != nil {
+		return fmt.Errorf("instrument %q: %w", i.Symbol, err)
+	}
+	c.cache(fresh)
+
+	if !fresh.Tradeable || (fresh.State != "" && fresh.State != "active") {
+		return fmt.Errorf("%w: %s is %s (tradability %q)", ErrNotTradable, fresh.Symbol, fresh.State, fresh.Tradability)
+	}
+	return nil
+}
+
+// InstrumentByID returns an Instrument given its ID.
+func (c *Client) InstrumentByID(ctx context.Context, id string) (Instrument, error) {
+	if c.InstrumentCache != nil {
+		if i, ok := c.InstrumentCache.ID(id); ok {
+			return i, nil
+		}
+	}
+	return c.InstrumentFromURL(ctx, baseURL("instruments")+id+"/")
+}
+
+// SearchInstruments returns the instruments whose symbol or name best match a
+// query, such as "apple", most relevant first.
+func (c *Client) SearchInstruments(ctx context.Context, query string) ([]Instrument, error) {
+	var r struct{ Results []Instrument }
+	q := url.Values{"query": []string{query}}
+	if err := c.get(ctx, baseURL("instruments")+"?"+q.Encode(), &r); err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	c.cache(r.Results...)
+	return r.Results, nil
+}
@@ -0,0 +1,194 @@
+package roho
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultInstrumentTTL is how long instruments are cached for by default.
+// Instruments rarely change, but their tradability occasionally does, so use
+// Client.CheckTradable before placing orders.
+const DefaultInstrumentTTL = 7 * 24 * time.Hour
+
+// minSaveInterval is how often Put persists the cache at most. Save persists
+// any remaining changes.
+const minSaveInterval = time.Minute
+
+// cachedInstrument is an instrument and when it was fetched.
+type cachedInstrument struct {
+	Instrument Instrument `json:"instrument"`
+	Fetched    time.Time  `json:"fetched"`
+}
+
+// InstrumentCache caches instruments by symbol, ID and URL, optionally
+// persisting them to a file so that they outlive the process.
+type InstrumentCache struct {
+	// Path is the file the cache is persisted to. If empty, the cache is only
+	// kept in memory.
+	Path string
+	TTL  time.Duration
+
+	mu   sync.Mutex
+	byID map[string]cachedInstrument
+	// bySymbol and byURL map to instrument IDs.
+	bySymbol map[string]string
+	byURL    map[string]string
+	// dirty is whether there are changes which have not been saved.
+	dirty bool
+	saved time.Time
+}
+
+// DefaultInstrumentCachePath returns the default location of the persistent
+// instrument cache.
+func DefaultInstrumentCachePath() (string, error) {
+	d, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("user cache dir: %w", err)
+	}
+	return filepath.Join(d, "roho", "instruments.json"), nil
+}
+
+// NewInstrumentCache returns an InstrumentCache, loading any instruments
+// previously persisted to path.
+func NewInstrumentCache(path string, ttl time.Duration) (*InstrumentCache, error) {
+	ic := &InstrumentCache{
+		Path:     path,
+		TTL:      ttl,
+		byID:     map[string]cachedInstrument{},
+		bySymbol: map[string]string{},
+		byURL:    map[string]string{},
+	}
+	if path == "" {
+		return ic, nil
+	}
+
+	bs, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ic, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cis []cachedInstrument
+	if err := json.Unmarshal(bs, &cis); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	for _, ci := range cis {
+		ic.add(ci)
+	}
+	return ic, nil
+}
+
+// add indexes a cached instrument. The caller must hold ic.mu.
+func (ic *InstrumentCache) add(ci cachedInstrument) {
+	i := ci.Instrument
+	if i.ID == "" {
+		return
+	}
+	ic.byID[i.ID] = ci
+	if i.Symbol != "" {
+		ic.bySymbol[strings.ToUpper(i.Symbol)] = i.ID
+	}
+	if i.URL != "" {
+		ic.byURL[i.URL] = i.ID
+	}
+}
+
+// lookup returns an unexpired instrument by ID. The caller must hold ic.mu.
+func (ic *InstrumentCache) lookup(id string) (Instrument, bool) {
+	ci, ok := ic.byID[id]
+	if !ok || (ic.TTL > 0 && time.Since(ci.Fetched) > ic.TTL) {
+		return Instrument{}, false
+	}
+	return ci.Instrument, true
+}
+
+// Symbol returns the cached instrument for a symbol, if any.
+func (ic *InstrumentCache) Symbol(sym string) (Instrument, bool) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return ic.lookup(ic.bySymbol[strings.ToUpper(sym)])
+}
+
+// ID returns the cached instrument for an instrument ID, if any.
+func (ic *InstrumentCache) ID(id string) (Instrument, bool) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return ic.lookup(id)
+}
+
+// URL returns the cached instrument for an instrument URL, if any.
+func (ic *InstrumentCache) URL(url string) (Instrument, bool) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+	return ic.lookup(ic.byURL[url])
+}
+
+// Put adds instruments to the cache. If the cache has a Path, it is persisted,
+// though no more than once a minute; call Save to persist any later changes.
+func (ic *InstrumentCache) Put(is ...Instrument) error {
+	if len(is) == 0 {
+		return nil
+	}
+
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	now := time.Now()
+	for _, i := range is {
+		ic.add(cachedInstrument{Instrument: i, Fetched: now})
+	}
+	ic.dirty = true
+
+	if ic.Path == "" || now.Sub(ic.saved) < minSaveInterval {
+		return nil
+	}
+	return ic.save()
+}
+
+// Save persists any changes to the cache, if it has a Path.
+func (ic *InstrumentCache) Save() error {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if ic.Path == "" || !ic.dirty {
+		return nil
+	}
+	return ic.save()
+}
+
+// save atomically persists the cache. The caller must hold ic.mu.
+func (ic *InstrumentCache) save() error {
+	if err := os.MkdirAll(filepath.Dir(ic.Path), 0o750); err != nil {
+		return fmt.Errorf("mkdir: %w", err)
+	}
+
+	cis := make([]cachedInstrument, 0, len(ic.byID))
+	for _, ci := range ic.byID {
+		cis = append(cis, ci)
+	}
+
+	bs, err := json.Marshal(cis)
+	if err != nil {
+		return err
+	}
+
+	tmp := ic.Path + ".tmp"
+	if err := ioutil.WriteFile(tmp, bs, 0o640); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, ic.Path); err != nil {
+		return err
+	}
+
+	ic.dirty = false
+	ic.saved = time.Now()
+	return nil
+}
@@ -0,0 +1,193 @@
+package roho
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func instrumentServer(t *testing.T, calls *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		switch {
+		case r.URL.Path == "/instruments/" && r.URL.Query().Get("symbol") != "":
+			sym := r.URL.Query().Get("symbol")
+			if sym == "NOPE" {
+				fmt.Fprint(w, `{"results": []}`)
+				return
+			}
+			id := strings.ToLower(sym)
+			fmt.Fprintf(w, `{"results": [{"id": %q, "symbol": %q, "url": "https://api.robinhood.com/instruments/%s/"}]}`, id, sym, id)
+		case r.URL.Path == "/instruments/" && r.URL.Query().Get("query") == "apple":
+			fmt.Fprint(w, `{"results": [{"id": "aapl", "symbol": "AAPL", "name": "Apple", "url": "https://api.robinhood.com/instruments/aapl/"}]}`)
+		case r.URL.Path == "/instruments/msft/":
+			fmt.Fprint(w, `{"id": "msft", "symbol": "MSFT", "url": "https://api.robinhood.com/instruments/msft/"}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestInstrumentsCached(t *testing.T) {
+	var calls int32
+	c := fakeClient(instrumentServer(t, &calls))
+
+	path := filepath.Join(t.TempDir(), "instruments.json")
+	ic, err := NewInstrumentCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewInstrumentCache() returned error: %v", err)
+	}
+	c.InstrumentCache = ic
+	ctx := context.Background()
+
+	is, err := c.Instruments(ctx, []string{"SPY", "QQQ", "IWM"})
+	if err != nil {
+		t.Fatalf("Instruments() returned error: %v", err)
+	}
+	got := []string{}
+	for _, i := range is {
+		got = append(got, i.Symbol)
+	}
+	if diff := cmp.Diff([]string{"SPY", "QQQ", "IWM"}, got); diff != "" {
+		t.Errorf("Instruments() symbols mismatch (-want +got):\n%s", diff)
+	}
+
+	// Lookups by symbol, ID and URL are now served from the cache, including by a new cache loaded from disk.
+	ic, err = NewInstrumentCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewInstrumentCache() returned error: %v", err)
+	}
+	c.InstrumentCache = ic
+
+	before := atomic.LoadInt32(&calls)
+	if _, err := c.Instruments(ctx, []string{"qqq", "SPY"}); err != nil {
+		t.Errorf("Instruments() returned error: %v", err)
+	}
+	if i, err := c.InstrumentByID(ctx, "iwm"); err != nil || i.Symbol != "IWM" {
+		t.Errorf("InstrumentByID() = %+v, %v", i, err)
+	}
+	if i, err := c.InstrumentFromURL(ctx, "https://api.robinhood.com/instruments/spy/"); err != nil || i.Symbol != "SPY" {
+		t.Errorf("InstrumentFromURL() = %+v, %v", i, err)
+	}
+	if n := atomic.LoadInt32(&calls) - before; n != 0 {
+		t.Errorf("cached lookups made %d API calls, want 0", n)
+	}
+
+	if i, err := c.InstrumentByID(ctx, "msft"); err != nil || i.Symbol != "MSFT" {
+		t.Errorf("InstrumentByID() of uncached instrument = %+v, %v", i, err)
+	}
+
+	if _, err := c.Instruments(ctx, []string{"SPY", "NOPE"}); err == nil {
+		t.Errorf("Instruments() of unknown symbol returned nil error")
+	}
+}
+
+func TestInstrumentCacheTTL(t *testing.T) {
+	ic, err := NewInstrumentCache("", time.Hour)
+	if err != nil {
+		t.Fatalf("NewInstrumentCache() returned error: %v", err)
+	}
+
+	ic.mu.Lock()
+	ic.add(cachedInstrument{Instrument: Instrument{ID: "old", Symbol: "OLD"}, Fetched: time.Now().Add(-2 * time.Hour)})
+	ic.mu.Unlock()
+
+	if _, ok := ic.Symbol("OLD"); ok {
+		t.Errorf("Symbol() returned an expired instrument")
+	}
+}
+
+func TestSearchInstruments(t *testing.T) {
+	var calls int32
+	c := fakeClient(instrumentServer(t, &calls))
+
+	is, err := c.SearchInstruments(context.Background(), "apple")
+	if err != nil {
+		t.Fatalf("SearchInstruments() returned error: %v", err)
+	}
+	if len(is) != 1 || is[0].Symbol != "AAPL" {
+		t.Errorf("SearchInstruments() = %+v, want AAPL", is)
+	}
+}
+
+func TestInstrumentCacheSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "instruments.json")
+	ic, err := NewInstrumentCache(path, time.Hour)
+	if err != nil {
+		t.Fatalf("NewInstrumentCache() returned error: %v", err)
+	}
+
+	load := func() *InstrumentCache {
+		ic, err := NewInstrumentCache(path, time.Hour)
+		if err != nil {
+			t.Fatalf("NewInstrumentCache() returned error: %v", err)
+		}
+		return ic
+	}
+
+	// The first Put is persisted, but the next is batched until Save.
+	if err := ic.Put(Instrument{ID: "spy", Symbol: "SPY"}); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	if err := ic.Put(Instrument{ID: "qqq", Symbol: "QQQ"}); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	if _, ok := load().Symbol("QQQ"); ok {
+		t.Errorf("second Put() was persisted immediately")
+	}
+
+	if err := ic.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	disk := load()
+	for _, sym := range []string{"SPY", "QQQ"} {
+		if _, ok := disk.Symbol(sym); !ok {
+			t.Errorf("%s was not persisted by Save()", sym)
+		}
+	}
+}
+
+func TestCheckTradable(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instruments/spy/":
+			fmt.Fprint(w, `{"id": "spy", "symbol": "SPY", "url": "https://api.robinhood.com/instruments/spy/", "state": "active", "tradeable": true}`)
+		case "/instruments/gone/":
+			fmt.Fprint(w, `{"id": "gone", "symbol": "GONE", "url": "https://api.robinhood.com/instruments/gone/", "state": "inactive", "tradeable": false, "tradability": "untradable"}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ic, err := NewInstrumentCache("", time.Hour)
+	if err != nil {
+		t.Fatalf("NewInstrumentCache() returned error: %v", err)
+	}
+	c.InstrumentCache = ic
+
+	// The cached copy of GONE claims it is still tradable.
+	if err := c.InstrumentCache.Put(Instrument{ID: "gone", Symbol: "GONE", URL: "https://api.robinhood.com/instruments/gone/", State: "active", Tradeable: true}); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+
+	if err := c.CheckTradable(ctx, &Instrument{Symbol: "SPY", URL: "https://api.robinhood.com/instruments/spy/"}); err != nil {
+		t.Errorf("CheckTradable(SPY) returned error: %v", err)
+	}
+	if err := c.CheckTradable(ctx, &Instrument{Symbol: "GONE", URL: "https://api.robinhood.com/instruments/gone/"}); !errors.Is(err, ErrNotTradable) {
+		t.Errorf("CheckTradable(GONE) = %v, want ErrNotTradable", err)
+	}
+	if i, ok := c.InstrumentCache.Symbol("GONE"); !ok || i.Tradeable {
+		t.Errorf("cache was not refreshed by CheckTradable(): %+v", i)
+	}
+}
@@ -2,12 +2,18 @@ package roho
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 )
 
+// ErrInsufficientDepth indicates that the pricebook does not offer enough
+// shares to fill a quantity.
+var ErrInsufficientDepth = errors.New("insufficient pricebook depth")
+
 type EntryPrice struct {
-	Amount       string
-	CurrencyCode string `json:"currency_code"`
+	Amount       float64 `json:"amount,string"`
+	CurrencyCode string  `json:"currency_code"`
 }
 
 type PriceBookEntry struct {
@@ -17,6 +23,8 @@ type PriceBookEntry struct {
 }
 
 type PriceBookData struct {
+	// Asks are sorted by ascending price, and Bids by descending price, so
+	// that the best price on each side comes first.
 	Asks []PriceBookEntry `json:"asks"`
 	Bids []PriceBookEntry `json:"bids"`
 
@@ -31,5 +39,135 @@ func (c *Client) Pricebook(ctx context.Context, instrumentID string) (*PriceBook
 	if err != nil {
 		return nil, err
 	}
+	out.sort()
 	return &out, nil
 }
+
+// sort orders each side of the book from the best price outward.
+func (p *PriceBookData) sort() {
+	sort.SliceStable(p.Asks, func(i, j int) bool { return p.Asks[i].Price.Amount < p.Asks[j].Price.Amount })
+	sort.SliceStable(p.Bids, func(i, j int) bool { return p.Bids[i].Price.Amount > p.Bids[j].Price.Amount })
+}
+
+// side returns the entries an order on the given side would fill against.
+func (p *PriceBookData) side(s OrderSide) []PriceBookEntry {
+	if s == Buy {
+		return p.Asks
+	}
+	return p.Bids
+}
+
+// BestBid returns the highest bid price, or 0 if there are no bids.
+func (p *PriceBookData) BestBid() float64 {
+	if len(p.Bids) == 0 {
+		return 0
+	}
+	return p.Bids[0].Price.Amount
+}
+
+// BestAsk returns the lowest ask price, or 0 if there are no asks.
+func (p *PriceBookData) BestAsk() float64 {
+	if len(p.Asks) == 0 {
+		return 0
+	}
+	return p.Asks[0].Price.Amount
+}
+
+// Mid returns the midpoint between the best bid and ask, or 0 if either side
+// of the book is empty.
+func (p *PriceBookData) Mid() float64 {
+	if len(p.Asks) == 0 || len(p.Bids) == 0 {
+		return 0
+	}
+	return (p.BestBid() + p.BestAsk()) / 2
+}
+
+// Spread returns the difference between the best ask and bid, or 0 if either
+// side of the book is empty.
+func (p *PriceBookData) Spread() float64 {
+	if len(p.Asks) == 0 || len(p.Bids) == 0 {
+		return 0
+	}
+	return p.BestAsk() - p.BestBid()
+}
+
+// SpreadPercent returns the spread as a percentage of the midpoint.
+func (p *PriceBookData) SpreadPercent() float64 {
+	if p.Mid() == 0 {
+		return 0
+	}
+	return p.Spread() / p.Mid() * 100
+}
+
+// depth returns the total quantity of the first levels of entries, or of all
+// entries if levels is 0.
+func depth(es []PriceBookEntry, levels int) float64 {
+	if levels <= 0 || levels > len(es) {
+		levels = len(es)
+	}
+
+	q := 0.0
+	for _, e := range es[:levels] {
+		q += e.Quantity
+	}
+	return q
+}
+
+// Depth returns the quantity available within the best levels of the bids and
+// asks, or within the whole book if levels is 0.
+func (p *PriceBookData) Depth(levels int) (bids float64, asks float64) {
+	return depth(p.Bids, levels), depth(p.Asks, levels)
+}
+
+// Imbalance returns the balance of bids against asks within the best levels,
+// from -1 (only asks) to 1 (only bids).
+func (p *PriceBookData) Imbalance(levels int) float64 {
+	b, a := p.Depth(levels)
+	if b+a == 0 {
+		return 0
+	}
+	return (b - a) / (b + a)
+}
+
+// FillPrice returns the volume-weighted average price at which an order for
+// quantity shares would fill against the book. If the book is not deep
+// enough, it returns the average price of the shares available along with an
+// error wrapping ErrInsufficientDepth.
+func (p *PriceBookData) FillPrice(s OrderSide, quantity float64) (float64, error) {
+	if quantity <= 0 {
+		return 0, fmt.Errorf("invalid quantity: %g", quantity)
+	}
+
+	filled, cost := 0.0, 0.0
+	for _, e := range p.side(s) {
+		q := e.Quantity
+		if filled+q > quantity {
+			q = quantity - filled
+		}
+		filled += q
+		cost += q * e.Price.Amount
+		if filled >= quantity {
+			return cost / filled, nil
+		}
+	}
+
+	if filled == 0 {
+		return 0, fmt.Errorf("%w: no %s liquidity", ErrInsufficientDepth, s)
+	}
+	return cost / filled, fmt.Errorf("%w: only %g of %g shares available", ErrInsufficientDepth, filled, quantity)
+}
+
+// Slippage returns how much worse, as a percentage, the average fill price for
+// quantity shares would be than the best price on the book.
+func (p *PriceBookData) Slippage(s OrderSide, quantity float64) (float64, error) {
+	fill, err := p.FillPrice(s, quantity)
+	if fill == 0 {
+		return 0, err
+	}
+
+	best := p.side(s)[0].Price.Amount
+	if s == Buy {
+		return (fill - best) / best * 100, err
+	}
+	return (best - fill) / best * 100, err
+}
@@ -0,0 +1,74 @@
+package roho
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+)
+
+func TestPricebook(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/marketdata/pricebook/snapshots/i1/" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"instrument_id": "i1", "asks": [
+			{"side": "ask", "price": {"amount": "10.02", "currency_code": "USD"}, "quantity": 300},
+			{"side": "ask", "price": {"amount": "10.01", "currency_code": "USD"}, "quantity": 100}
+		], "bids": [
+			{"side": "bid", "price": {"amount": "9.98", "currency_code": "USD"}, "quantity": 200},
+			{"side": "bid", "price": {"amount": "9.99", "currency_code": "USD"}, "quantity": 400}
+		]}`)
+	})
+
+	p, err := c.Pricebook(context.Background(), "i1")
+	if err != nil {
+		t.Fatalf("Pricebook() returned error: %v", err)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.0001 }
+
+	if p.BestAsk() != 10.01 || p.BestBid() != 9.99 {
+		t.Errorf("best ask/bid = %v/%v, want 10.01/9.99", p.BestAsk(), p.BestBid())
+	}
+	if !near(p.Spread(), 0.02) || !near(p.Mid(), 10) || !near(p.SpreadPercent(), 0.2) {
+		t.Errorf("spread = %v (%v%%), mid = %v; want 0.02 (0.2%%), 10", p.Spread(), p.SpreadPercent(), p.Mid())
+	}
+
+	if b, a := p.Depth(1); b != 400 || a != 100 {
+		t.Errorf("Depth(1) = %v, %v; want 400, 100", b, a)
+	}
+	if got := p.Imbalance(0); !near(got, 0.2) {
+		t.Errorf("Imbalance(0) = %v, want 0.2", got)
+	}
+
+	fill, err := p.FillPrice(Buy, 200)
+	if err != nil || !near(fill, 10.015) {
+		t.Errorf("FillPrice(Buy, 200) = %v, %v; want 10.015, nil", fill, err)
+	}
+	slip, err := p.Slippage(Sell, 500)
+	if err != nil || !near(slip, (9.99-9.988)/9.99*100) {
+		t.Errorf("Slippage(Sell, 500) = %v, %v", slip, err)
+	}
+
+	fill, err = p.FillPrice(Buy, 500)
+	if !errors.Is(err, ErrInsufficientDepth) || !near(fill, 10.0175) {
+		t.Errorf("FillPrice(Buy, 500) = %v, %v; want 10.0175, %v", fill, err, ErrInsufficientDepth)
+	}
+
+	if _, err := p.FillPrice(Buy, 0); err == nil {
+		t.Errorf("FillPrice(Buy, 0) returned nil error")
+	}
+	if slip, err := p.Slippage(Sell, -1); err == nil || slip != 0 {
+		t.Errorf("Slippage(Sell, -1) = %v, %v; want 0, error", slip, err)
+	}
+
+	oneSided := &PriceBookData{Asks: p.Asks}
+	if oneSided.Mid() != 0 || oneSided.SpreadPercent() != 0 {
+		t.Errorf("one-sided mid = %v (%v%%), want 0", oneSided.Mid(), oneSided.SpreadPercent())
+	}
+}
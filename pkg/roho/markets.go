@@ -0,0 +1,155 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+// MarketNYSE is the market identifier code of the New York Stock Exchange.
+const MarketNYSE = "XNYS"
+
+// marketHoursTTL is how long market hours are cached for. Hours for a date
+// rarely change, though early closes may be announced in advance.
+const marketHoursTTL = 12 * time.Hour
+
+// MarketHours are the trading hours of a market on a single day.
+type MarketHours struct {
+	Date   Date `json:"date"`
+	IsOpen bool `json:"is_open"`
+	// OpensAt and ClosesAt bound regular trading, and are zero if closed.
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+	// ExtendedOpensAt and ExtendedClosesAt bound Robinhood's extended-hours trading.
+	ExtendedOpensAt   time.Time `json:"extended_opens_at"`
+	ExtendedClosesAt  time.Time `json:"extended_closes_at"`
+	NextOpenHours     string    `json:"next_open_hours"`
+	PreviousOpenHours string    `json:"previous_open_hours"`
+}
+
+// IsRegularTradingTime returns whether t is within regular trading.
+func (h MarketHours) IsRegularTradingTime(t time.Time) bool {
+	return h.IsOpen && !t.Before(h.OpensAt) && t.Before(h.ClosesAt)
+}
+
+// cachedHours are market hours and when they were fetched.
+type cachedHours struct {
+	hours   *MarketHours
+	fetched time.Time
+}
+
+// hoursCache caches market hours by market and date.
+type hoursCache struct {
+	mu sync.Mutex
+	m  map[string]cachedHours
+}
+
+// marketDate returns the date of t in New York, as used by market hours.
+func marketDate(t time.Time) string {
+	et, err := time.LoadLocation("America/New_York")
+	if err == nil {
+		t = t.In(et)
+	}
+	return t.Format(dateFormat)
+}
+
+// MarketHours returns the trading hours of a market, such as MarketNYSE, on
+// the New York date containing date. Results are cached.
+func (c *Client) MarketHours(ctx context.Context, market string, date time.Time) (*MarketHours, error) {
+	key := market + "/" + marketDate(date)
+
+	c.hours.mu.Lock()
+	ch, ok := c.hours.m[key]
+	c.hours.mu.Unlock()
+	if ok && time.Since(ch.fetched) < marketHoursTTL {
+		return ch.hours, nil
+	}
+
+	var h MarketHours
+	if err := c.get(ctx, baseURL("markets/"+market+"/hours/"+marketDate(date)), &h); err != nil {
+		return nil, fmt.Errorf("market hours: %w", err)
+	}
+
+	c.hours.mu.Lock()
+	defer c.hours.mu.Unlock()
+	if c.hours.m == nil {
+		c.hours.m = map[string]cachedHours{}
+	}
+	c.hours.m[key] = cachedHours{hours: &h, fetched: time.Now()}
+	return &h, nil
+}
+
+// IsMarketOpen returns whether the New York Stock Exchange is currently open
+// for regular trading, accounting for holidays and early closes.
+func (c *Client) IsMarketOpen(ctx context.Context) (bool, error) {
+	now := time.Now()
+	h, err := c.MarketHours(ctx, MarketNYSE, now)
+	if err != nil {
+		return false, err
+	}
+	return h.IsRegularTradingTime(now), nil
+}
+
+// marketHoursTimeout bounds each lookup made on behalf of pkg/times.
+const marketHoursTimeout = 5 * time.Second
+
+// marketHoursBackoff is how long a marketHoursSource waits after a failed
+// lookup before calling the API again. pkg/times assumes regular hours meanwhile.
+const marketHoursBackoff = 5 * time.Minute
+
+// marketHoursSource adapts a Client to times.HoursSource. As pkg/times is
+// consulted frequently, such as by Quote.Price, failures are remembered so
+// that an unavailable API does not slow every caller down.
+type marketHoursSource struct {
+	c      *Client
+	market string
+
+	mu       sync.Mutex
+	err      error
+	failedAt time.Time
+}
+
+// Hours implements times.HoursSource.
+func (s *marketHoursSource) Hours(date time.Time) (times.Hours, error) {
+	s.mu.Lock()
+	if s.err != nil && time.Since(s.failedAt) < marketHoursBackoff {
+		err := s.err
+		s.mu.Unlock()
+		return times.Hours{}, err
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), marketHoursTimeout)
+	defer cancel()
+
+	h, err := s.c.MarketHours(ctx, s.market, date)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.err = err
+		s.failedAt = time.Now()
+		return times.Hours{}, err
+	}
+	s.err = nil
+
+	day := times.DefaultHours(date).Date
+	return times.Hours{
+		Date:           day,
+		IsOpen:         h.IsOpen,
+		Opens:          h.OpensAt,
+		Closes:         h.ClosesAt,
+		ExtendedOpens:  h.ExtendedOpensAt,
+		ExtendedCloses: h.ExtendedClosesAt,
+	}, nil
+}
+
+// HoursSource returns a times.HoursSource backed by the hours of a market.
+// Pass it to times.SetHoursSource to make pkg/times aware of holidays and
+// early closes. If the API fails, it is not called again for a few minutes.
+func (c *Client) HoursSource(market string) times.HoursSource {
+	return &marketHoursSource{c: c, market: market}
+}
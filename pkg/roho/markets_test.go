@@ -0,0 +1,88 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+func TestMarketHours(t *testing.T) {
+	calls := map[string]int{}
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/markets/XNYS/hours/2021-11-25/":
+			fmt.Fprint(w, `{"date": "2021-11-25", "is_open": false, "opens_at": null, "closes_at": null,
+				"extended_opens_at": null, "extended_closes_at": null}`)
+		case "/markets/XNYS/hours/2021-11-26/":
+			fmt.Fprint(w, `{"date": "2021-11-26", "is_open": true, "opens_at": "2021-11-26T14:30:00Z", "closes_at": "2021-11-26T18:00:00Z",
+				"extended_opens_at": "2021-11-26T14:00:00Z", "extended_closes_at": "2021-11-26T22:00:00Z"}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	// Thanksgiving, at 3PM in New York.
+	thanksgiving := time.Date(2021, 11, 25, 20, 0, 0, 0, time.UTC)
+	h, err := c.MarketHours(ctx, MarketNYSE, thanksgiving)
+	if err != nil {
+		t.Fatalf("MarketHours() returned error: %v", err)
+	}
+	if h.IsOpen || h.IsRegularTradingTime(thanksgiving) {
+		t.Errorf("MarketHours() = %+v, want closed", h)
+	}
+
+	// An early close, at 2PM in New York.
+	halfDay := time.Date(2021, 11, 26, 19, 0, 0, 0, time.UTC)
+	h, err = c.MarketHours(ctx, MarketNYSE, halfDay)
+	if err != nil {
+		t.Fatalf("MarketHours() returned error: %v", err)
+	}
+	if !h.IsOpen || h.IsRegularTradingTime(halfDay) || !h.IsRegularTradingTime(halfDay.Add(-2*time.Hour)) {
+		t.Errorf("MarketHours() = %+v, want open until 1PM", h)
+	}
+
+	// Late in the evening UTC is still the same day in New York, and cached.
+	if _, err := c.MarketHours(ctx, MarketNYSE, time.Date(2021, 11, 27, 2, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("MarketHours() returned error: %v", err)
+	}
+	if got := calls["/markets/XNYS/hours/2021-11-26/"]; got != 1 {
+		t.Errorf("fetched hours %d times, want 1", got)
+	}
+
+	times.SetHoursSource(c.HoursSource(MarketNYSE))
+	defer times.SetHoursSource(nil)
+
+	if times.IsTradingDay(thanksgiving) {
+		t.Errorf("IsTradingDay(%s) = true, want false", thanksgiving)
+	}
+	if got := times.HoursOn(halfDay).Closes; !got.Equal(time.Date(2021, 11, 26, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("HoursOn(%s).Closes = %s", halfDay, got)
+	}
+}
+
+func TestHoursSourceBackoff(t *testing.T) {
+	calls := 0
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"detail": "Service unavailable."}`)
+	})
+
+	s := c.HoursSource(MarketNYSE)
+	day := time.Date(2021, 11, 26, 15, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		if _, err := s.Hours(day); err == nil {
+			t.Errorf("Hours() returned nil error from a failing API")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Hours() made %d calls while backing off, want 1", calls)
+	}
+}
@@ -0,0 +1,91 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Directions that movers may be requested in.
+const (
+	MoversUp   = "up"
+	MoversDown = "down"
+)
+
+// Mover is one of the S&P 500 stocks that has moved the most today.
+type Mover struct {
+	InstrumentURL string    `json:"instrument_url"`
+	Symbol        string    `json:"symbol"`
+	Description   string    `json:"description"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	PriceMovement struct {
+		Percent float64 `json:"market_hours_last_movement_pct,string"`
+		Price   float64 `json:"market_hours_last_price,string"`
+	} `json:"price_movement"`
+}
+
+// Movers returns the S&P 500 stocks that have moved the most today in a
+// direction, MoversUp or MoversDown.
+func (c *Client) Movers(ctx context.Context, direction string) ([]Mover, error) {
+	if direction != MoversUp && direction != MoversDown {
+		return nil, fmt.Errorf("unknown movers direction %q", direction)
+	}
+
+	var r struct{ Results []Mover }
+	q := url.Values{"direction": []string{direction}}
+	if err := c.get(ctx, baseURL("midlands/movers/sp500")+"?"+q.Encode(), &r); err != nil {
+		return nil, fmt.Errorf("movers: %w", err)
+	}
+	return r.Results, nil
+}
+
+// Tag is a Robinhood collection of instruments, such as "100-most-popular" or
+// "technology".
+type Tag struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Instruments are instrument URLs.
+	Instruments []string `json:"instruments"`
+}
+
+// Tag returns the tag collection with the given slug.
+func (c *Client) Tag(ctx context.Context, slug string) (*Tag, error) {
+	var t Tag
+	if err := c.get(ctx, baseURL("midlands/tags/tag/"+url.PathEscape(strings.ToLower(slug))), &t); err != nil {
+		return nil, fmt.Errorf("tag %q: %w", slug, err)
+	}
+	return &t, nil
+}
+
+// maxPopularityIDs is the number of instruments requested per popularity API call.
+const maxPopularityIDs = 50
+
+// Popularity returns the number of Robinhood accounts holding each of the
+// instruments, keyed by instrument URL.
+func (c *Client) Popularity(ctx context.Context, is ...Instrument) (map[string]int, error) {
+	ids := []string{}
+	for _, i := range is {
+		ids = append(ids, i.ID)
+	}
+
+	ps := map[string]int{}
+	for _, ck := range chunkStrings(ids, maxPopularityIDs) {
+		var r struct {
+			Results []struct {
+				Instrument       string `json:"instrument"`
+				NumOpenPositions int    `json:"num_open_positions"`
+			}
+		}
+		q := url.Values{"ids": []string{strings.Join(ck, ",")}}
+		if err := c.get(ctx, baseURL("instruments/popularity")+"?"+q.Encode(), &r); err != nil {
+			return ps, fmt.Errorf("popularity: %w", err)
+		}
+		for _, p := range r.Results {
+			ps[p.Instrument] = p.NumOpenPositions
+		}
+	}
+	return ps, nil
+}
@@ -0,0 +1,82 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMoversTagsPopularity(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/midlands/movers/sp500/":
+			if r.URL.Query().Get("direction") != "down" {
+				t.Errorf("unexpected direction: %s", r.URL)
+			}
+			fmt.Fprint(w, `{"results": [{"instrument_url": "https://api.robinhood.com/instruments/x/", "symbol": "XYZ",
+				"price_movement": {"market_hours_last_movement_pct": "-7.25", "market_hours_last_price": "12.50"}}]}`)
+		case "/midlands/tags/tag/technology/":
+			fmt.Fprint(w, `{"slug": "technology", "name": "Technology", "instruments": [
+				"https://api.robinhood.com/instruments/aapl/", "https://api.robinhood.com/instruments/msft/"]}`)
+		case "/instruments/aapl/", "/instruments/msft/":
+			id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/instruments/"), "/")
+			fmt.Fprintf(w, `{"id": %q, "symbol": %q, "url": "https://api.robinhood.com%s"}`, id, strings.ToUpper(id), r.URL.Path)
+		case "/instruments/popularity/":
+			if got := r.URL.Query().Get("ids"); got != "aapl,msft" {
+				t.Errorf("popularity ids = %q, want aapl,msft", got)
+			}
+			fmt.Fprint(w, `{"results": [{"instrument": "https://api.robinhood.com/instruments/aapl/", "num_open_positions": 1000},
+				{"instrument": "https://api.robinhood.com/instruments/msft/", "num_open_positions": 500}]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ms, err := c.Movers(ctx, MoversDown)
+	if err != nil {
+		t.Fatalf("Movers() returned error: %v", err)
+	}
+	if len(ms) != 1 || ms[0].Symbol != "XYZ" || ms[0].PriceMovement.Percent != -7.25 {
+		t.Errorf("Movers() = %+v", ms)
+	}
+	if _, err := c.Movers(ctx, "sideways"); err == nil {
+		t.Errorf("Movers() with unknown direction returned nil error")
+	}
+
+	tag, err := c.Tag(ctx, "Technology")
+	if err != nil {
+		t.Fatalf("Tag() returned error: %v", err)
+	}
+	is, err := c.InstrumentsFromURLs(ctx, tag.Instruments)
+	if err != nil {
+		t.Fatalf("InstrumentsFromURLs() returned error: %v", err)
+	}
+
+	ps, err := c.Popularity(ctx, is...)
+	if err != nil {
+		t.Fatalf("Popularity() returned error: %v", err)
+	}
+	want := map[string]int{"https://api.robinhood.com/instruments/aapl/": 1000, "https://api.robinhood.com/instruments/msft/": 500}
+	if diff := cmp.Diff(want, ps); diff != "" {
+		t.Errorf("Popularity() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTagEscaped(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.EscapedPath(), "/midlands/tags/tag/a%2Fb%3Fc%23d/"; got != want {
+			t.Errorf("requested %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"slug": "a/b?c#d"}`)
+	})
+
+	if _, err := c.Tag(context.Background(), "A/b?c#d"); err != nil {
+		t.Fatalf("Tag() returned error: %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Option types understood by the options instruments API.
+const (
+	Call = "call"
+	Put  = "put"
+)
+
+// Moneyness selects options by their strike price relative to the price of
+// the underlying instrument.
+type Moneyness int
+
+// Moneyness filters. Default is AnyMoneyness.
+const (
+	AnyMoneyness Moneyness = iota
+	InTheMoney
+	OutOfTheMoney
+)
+
+// ChainQuery describes the subset of an option chain to explore. Zero values
+// are unbounded.
+type ChainQuery struct {
+	// Type is Call, Put, or empty for both.
+	Type string
+	// MinDTE and MaxDTE bound the number of days to expiration.
+	MinDTE, MaxDTE int
+	// MinStrike and MaxStrike bound the strike price.
+	MinStrike, MaxStrike float64
+	// StrikeWindow limits strikes to within this percentage of the underlying price.
+	StrikeWindow float64
+	// Moneyness limits strikes to those in or out of the money.
+	Moneyness Moneyness
+}
+
+// ChainOption is a single option contract within a ChainGrid.
+type ChainOption struct {
+	Instrument *OptionInstrument
+	MarketData *MarketData
+}
+
+// ChainCell holds the call and put for a single strike and expiration. Either
+// may be nil.
+type ChainCell struct {
+	Call *ChainOption
+	Put  *ChainOption
+}
+
+// ChainGrid is a strike-by-expiration view of an option chain.
+type ChainGrid struct {
+	Symbol      string
+	Underlying  float64
+	Expirations []Date
+	Strikes     []float64
+	// Cells is indexed by strike then expiration, in the order of Strikes and Expirations.
+	Cells [][]ChainCell
+}
+
+// Cell returns the cell for a given strike and expiration, or nil if the grid
+// has no such cell.
+func (g *ChainGrid) Cell(strike float64, exp Date) *ChainCell {
+	si := sort.SearchFloat64s(g.Strikes, strike)
+	if si == len(g.Strikes) || g.Strikes[si] != strike {
+		return nil
+	}
+
+	for ei, e := range g.Expirations {
+		if e.Equal(exp.Time) {
+			return &g.Cells[si][ei]
+		}
+	}
+	return nil
+}
+
+// Expirations returns the expiration dates of the chain, in the order
+// provided by the API.
+func (o *OptionChain) Expirations() ([]Date, error) {
+	ds := make([]Date, 0, len(o.ExpirationDates))
+	for _, s := range o.ExpirationDates {
+		t, err := time.Parse(dateFormat, s)
+		if err != nil {
+			return nil, fmt.Errorf("parse %q: %w", s, err)
+		}
+		ds = append(ds, Date{t})
+	}
+	return ds, nil
+}
+
+// DaysToExpiration returns the number of calendar days between now and the
+// expiration date of the option.
+func (oi *OptionInstrument) DaysToExpiration(now time.Time) int {
+	return daysUntil(oi.ExpirationDate, now)
+}
+
+// daysUntil returns the number of calendar days from now until a date.
+func daysUntil(d Date, now time.Time) int {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(today).Hours() / 24)
+}
+
+// expirations returns the dates within the DTE range of the query.
+func (q ChainQuery) expirations(ds []Date, now time.Time) []Date {
+	out := []Date{}
+	for _, d := range ds {
+		dte := daysUntil(d, now)
+		if dte < q.MinDTE {
+			continue
+		}
+		if q.MaxDTE > 0 && dte > q.MaxDTE {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// match returns whether an option instrument matches the query, given the
+// price of the underlying instrument.
+func (q ChainQuery) match(oi *OptionInstrument, underlying float64) bool {
+	if q.Type != "" && oi.Type != q.Type {
+		return false
+	}
+
+	strike := oi.StrikePrice
+	if q.MinStrike > 0 && strike < q.MinStrike {
+		return false
+	}
+	if q.MaxStrike > 0 && strike > q.MaxStrike {
+		return false
+	}
+
+	if q.StrikeWindow > 0 {
+		diff := (strike - underlying) / underlying * 100
+		if diff > q.StrikeWindow || diff < -q.StrikeWindow {
+			return false
+		}
+	}
+
+	switch q.Moneyness {
+	case InTheMoney:
+		return (oi.Type == Call && strike < underlying) || (oi.Type == Put && strike > underlying)
+	case OutOfTheMoney:
+		return (oi.Type == Call && strike >= underlying) || (oi.Type == Put && strike <= underlying)
+	default:
+		return true
+	}
+}
+
+// needsUnderlying returns whether matching the query depends on the price of
+// the underlying instrument.
+func (q ChainQuery) needsUnderlying() bool {
+	return q.StrikeWindow > 0 || q.Moneyness != AnyMoneyness
+}
+
+// Explore returns a strike-by-expiration grid of the options in the chain
+// that match the query, along with their current market data. If market data
+// is only partially available, the grid is returned along with the error.
+func (o *OptionChain) Explore(ctx context.Context, q ChainQuery) (*ChainGrid, error) {
+	return o.ExploreAt(ctx, q, 0)
+}
+
+// ExploreAt is like Explore, but uses an already known price of the underlying
+// instrument rather than fetching a quote for it, unless the price is 0.
+func (o *OptionChain) ExploreAt(ctx context.Context, q ChainQuery, underlying float64) (*ChainGrid, error) {
+	all, err := o.Expirations()
+	if err != nil {
+		return nil, fmt.Errorf("expirations: %w", err)
+	}
+
+	g := &ChainGrid{Symbol: o.Symbol, Expirations: q.expirations(all, time.Now())}
+	if len(g.Expirations) == 0 {
+		return g, nil
+	}
+
+	// The underlying price is informational unless the query depends on it.
+	g.Underlying = underlying
+	if g.Underlying <= 0 {
+		uq, err := o.c.Quote(ctx, o.Symbol)
+		if err != nil {
+			if q.needsUnderlying() {
+				return nil, fmt.Errorf("quote: %w", err)
+			}
+			klog.Warningf("%s: underlying quote unavailable: %v", o.Symbol, err)
+		}
+		g.Underlying = uq.Price()
+	}
+	if g.Underlying <= 0 && q.needsUnderlying() {
+		return nil, fmt.Errorf("no price for %q", o.Symbol)
+	}
+
+	ds := make([]string, len(g.Expirations))
+	for i, d := range g.Expirations {
+		ds[i] = d.String()
+	}
+
+	v := url.Values{
+		"chain_id":         []string{o.ID},
+		"expiration_dates": []string{strings.Join(ds, ",")},
+	}
+	if q.Type != "" {
+		v.Set("type", q.Type)
+	}
+
+	ois, err := o.instruments(ctx, v)
+	if err != nil {
+		return nil, fmt.Errorf("instruments: %w", err)
+	}
+
+	matched := []*OptionInstrument{}
+	for _, oi := range ois {
+		if q.match(oi, g.Underlying) {
+			matched = append(matched, oi)
+		}
+	}
+
+	// Partial market data is still worth returning: missing cells have a nil MarketData.
+	mds, err := o.c.MarketData(ctx, matched...)
+	g.fill(matched, mds)
+	if err != nil {
+		return g, fmt.Errorf("market data: %w", err)
+	}
+	return g, nil
+}
+
+// fill populates the strikes and cells of the grid from a set of option
+// instruments.
+func (g *ChainGrid) fill(ois []*OptionInstrument, mds map[string]*MarketData) {
+	seen := map[float64]bool{}
+	for _, oi := range ois {
+		if !seen[oi.StrikePrice] {
+			seen[oi.StrikePrice] = true
+			g.Strikes = append(g.Strikes, oi.StrikePrice)
+		}
+	}
+	sort.Float64s(g.Strikes)
+
+	g.Cells = make([][]ChainCell, len(g.Strikes))
+	for i := range g.Cells {
+		g.Cells[i] = make([]ChainCell, len(g.Expirations))
+	}
+
+	for _, oi := range ois {
+		c := g.Cell(oi.StrikePrice, oi.ExpirationDate)
+		if c == nil {
+			continue
+		}
+
+		co := &ChainOption{Instrument: oi, MarketData: mds[oi.URL]}
+		if oi.Type == Put {
+			c.Put = co
+		} else {
+			c.Call = co
+		}
+	}
+}
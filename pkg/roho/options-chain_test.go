@@ -0,0 +1,180 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChainQueryExpirations(t *testing.T) {
+	now := time.Date(2021, 9, 1, 15, 0, 0, 0, time.UTC)
+	ds := []Date{
+		NewZonedDate(2021, 9, 1, time.UTC),
+		NewZonedDate(2021, 9, 10, time.UTC),
+		NewZonedDate(2021, 10, 15, time.UTC),
+		NewZonedDate(2022, 1, 21, time.UTC),
+	}
+
+	tests := []struct {
+		q    ChainQuery
+		want []string
+	}{
+		{q: ChainQuery{}, want: []string{"2021-09-01", "2021-09-10", "2021-10-15", "2022-01-21"}},
+		{q: ChainQuery{MinDTE: 1}, want: []string{"2021-09-10", "2021-10-15", "2022-01-21"}},
+		{q: ChainQuery{MaxDTE: 45}, want: []string{"2021-09-01", "2021-09-10", "2021-10-15"}},
+		{q: ChainQuery{MinDTE: 7, MaxDTE: 30}, want: []string{"2021-09-10"}},
+	}
+
+	for _, tc := range tests {
+		got := []string{}
+		for _, d := range tc.q.expirations(ds, now) {
+			got = append(got, d.String())
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("expirations(%+v) diff: %s", tc.q, diff)
+		}
+	}
+}
+
+func TestChainQueryMatch(t *testing.T) {
+	ois := []*OptionInstrument{
+		{URL: "c90", Type: Call, StrikePrice: 90},
+		{URL: "c100", Type: Call, StrikePrice: 100},
+		{URL: "c110", Type: Call, StrikePrice: 110},
+		{URL: "p90", Type: Put, StrikePrice: 90},
+		{URL: "p110", Type: Put, StrikePrice: 110},
+	}
+
+	tests := []struct {
+		q    ChainQuery
+		want []string
+	}{
+		{q: ChainQuery{}, want: []string{"c90", "c100", "c110", "p90", "p110"}},
+		{q: ChainQuery{Type: Put}, want: []string{"p90", "p110"}},
+		{q: ChainQuery{MinStrike: 95, MaxStrike: 105}, want: []string{"c100"}},
+		{q: ChainQuery{StrikeWindow: 5}, want: []string{"c100"}},
+		{q: ChainQuery{Moneyness: InTheMoney}, want: []string{"c90", "c100", "p110"}},
+		{q: ChainQuery{Moneyness: OutOfTheMoney, Type: Call}, want: []string{"c110"}},
+	}
+
+	for _, tc := range tests {
+		got := []string{}
+		for _, oi := range ois {
+			if tc.q.match(oi, 101) {
+				got = append(got, oi.URL)
+			}
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("match(%+v) diff: %s", tc.q, diff)
+		}
+	}
+}
+
+func TestChainGridFill(t *testing.T) {
+	sep := NewZonedDate(2021, 9, 17, time.UTC)
+	oct := NewZonedDate(2021, 10, 15, time.UTC)
+
+	ois := []*OptionInstrument{
+		{URL: "c110-oct", Type: Call, StrikePrice: 110, ExpirationDate: oct},
+		{URL: "c100-sep", Type: Call, StrikePrice: 100, ExpirationDate: sep},
+		{URL: "p100-sep", Type: Put, StrikePrice: 100, ExpirationDate: sep},
+	}
+	mds := map[string]*MarketData{"c100-sep": {Instrument: "c100-sep", MarkPrice: 1.5}}
+
+	g := &ChainGrid{Expirations: []Date{sep, oct}}
+	g.fill(ois, mds)
+
+	if diff := cmp.Diff([]float64{100, 110}, g.Strikes); diff != "" {
+		t.Errorf("strikes diff: %s", diff)
+	}
+
+	c := g.Cell(100, sep)
+	if c == nil || c.Call == nil || c.Put == nil {
+		t.Fatalf("Cell(100, sep) = %+v, want call and put", c)
+	}
+	if c.Call.MarketData == nil || c.Call.MarketData.MarkPrice != 1.5 {
+		t.Errorf("Cell(100, sep).Call.MarketData = %+v, want mark price 1.5", c.Call.MarketData)
+	}
+
+	if c := g.Cell(100, oct); c == nil || c.Call != nil || c.Put != nil {
+		t.Errorf("Cell(100, oct) = %+v, want empty cell", c)
+	}
+
+	if c := g.Cell(105, sep); c != nil {
+		t.Errorf("Cell(105, sep) = %+v, want nil", c)
+	}
+}
+
+func TestExploreWithoutUnderlyingPrice(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/quotes/" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"results": [{"symbol": "XYZ", "last_trade_price": "0"}]}`)
+	})
+
+	o := &OptionChain{Symbol: "XYZ", ExpirationDates: []string{"2099-01-16"}, c: c}
+	if _, err := o.Explore(context.Background(), ChainQuery{StrikeWindow: 10}); err == nil {
+		t.Errorf("Explore() with a strike window and no underlying price returned nil error")
+	}
+}
+
+func TestExploreAt(t *testing.T) {
+	// The quote of the underlying instrument is not requested.
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/options/instruments/":
+			fmt.Fprint(w, `{"results": [
+				{"url": "https://example.com/near", "type": "call", "strike_price": "105.00", "expiration_date": "2099-01-16"},
+				{"url": "https://example.com/far", "type": "call", "strike_price": "200.00", "expiration_date": "2099-01-16"}
+			]}`)
+		case "/marketdata/options/":
+			fmt.Fprint(w, `{"results": []}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	o := &OptionChain{Symbol: "XYZ", ExpirationDates: []string{"2099-01-16"}, c: c}
+	// Missing market data is returned as a partial failure.
+	g, _ := o.ExploreAt(context.Background(), ChainQuery{StrikeWindow: 10}, 100)
+	if g == nil || g.Underlying != 100 {
+		t.Fatalf("ExploreAt() = %+v, want an underlying price of 100", g)
+	}
+	if diff := cmp.Diff([]float64{105}, g.Strikes); diff != "" {
+		t.Errorf("ExploreAt() strikes diff: %s", diff)
+	}
+}
+
+func TestExploreWithoutQuote(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quotes/":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/options/instruments/":
+			fmt.Fprint(w, `{"results": [{"url": "https://example.com/opt", "type": "put", "strike_price": "50.00", "expiration_date": "2099-01-16"}]}`)
+		case "/marketdata/options/":
+			fmt.Fprint(w, `{"results": []}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// A strike-only query does not depend on the underlying price.
+	o := &OptionChain{Symbol: "XYZ", ExpirationDates: []string{"2099-01-16"}, c: c}
+	g, _ := o.Explore(context.Background(), ChainQuery{MaxStrike: 100})
+	if g == nil {
+		t.Fatalf("Explore() without a quote returned no grid")
+	}
+	if diff := cmp.Diff([]float64{50}, g.Strikes); diff != "" {
+		t.Errorf("Explore() strikes diff: %s", diff)
+	}
+}
@@ -0,0 +1,154 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Well-known option event types.
+const (
+	OptionExpiration = "expiration"
+	OptionAssignment = "assignment"
+	OptionExercise   = "exercise"
+)
+
+// OptionEvent is an expiration, assignment or exercise of an option position.
+type OptionEvent struct {
+	Meta
+	ID               string            `json:"id"`
+	Account          string            `json:"account"`
+	ChainID          string            `json:"chain_id"`
+	Direction        string            `json:"direction"`
+	EquityComponents []EquityComponent `json:"equity_components"`
+	EventDate        Date              `json:"event_date"`
+	OptionURL        string            `json:"option"`
+	PositionURL      string            `json:"position"`
+	Quantity         float64           `json:"quantity,string"`
+	State            string            `json:"state"`
+	TotalCashAmount  float64           `json:"total_cash_amount,string"`
+	Type             string            `json:"type"`
+	UnderlyingPrice  float64           `json:"underlying_price,string"`
+
+	// Option is the option instrument affected by the event.
+	Option *OptionInstrument `json:"-"`
+}
+
+// EquityComponent is the change in an equity position caused by an option
+// event, such as shares delivered by an assignment.
+type EquityComponent struct {
+	ID            string  `json:"id"`
+	InstrumentURL string  `json:"instrument"`
+	Price         float64 `json:"price,string"`
+	Quantity      float64 `json:"quantity,string"`
+	Side          string  `json:"side"`
+	Symbol        string  `json:"symbol"`
+}
+
+// SharesDelta returns the signed change in shares held: positive for buys and
+// negative for sells.
+func (e EquityComponent) SharesDelta() float64 {
+	if e.Side == "sell" {
+		return -e.Quantity
+	}
+	return e.Quantity
+}
+
+// OptionEventsQuery filters the option events returned by OptionEvents. Zero
+// values match everything.
+type OptionEventsQuery struct {
+	// Type is one of OptionExpiration, OptionAssignment or OptionExercise.
+	Type    string
+	ChainID string
+	// Since and Until bound the event date, inclusively.
+	Since, Until time.Time
+}
+
+func (q OptionEventsQuery) encode() string {
+	v := url.Values{}
+	if q.Type != "" {
+		v.Set("type", q.Type)
+	}
+	if q.ChainID != "" {
+		v.Set("chain_ids", q.ChainID)
+	}
+	return v.Encode()
+}
+
+// match returns whether an event matches the query. The API does not honor
+// every filter, so they are applied again locally.
+func (q OptionEventsQuery) match(e OptionEvent) bool {
+	if q.Type != "" && e.Type != q.Type {
+		return false
+	}
+	if q.ChainID != "" && e.ChainID != q.ChainID {
+		return false
+	}
+	if !q.Since.IsZero() && e.EventDate.Before(truncateDay(q.Since)) {
+		return false
+	}
+	if !q.Until.IsZero() && e.EventDate.After(truncateDay(q.Until)) {
+		return false
+	}
+	return true
+}
+
+// truncateDay returns midnight UTC of the calendar day of t, to compare
+// against a Date.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// OptionEvents returns option expirations, assignments and exercises that
+// match the query, with the affected option instrument resolved.
+func (c *Client) OptionEvents(ctx context.Context, q OptionEventsQuery) ([]OptionEvent, error) {
+	u, err := url.Parse(baseURL("options") + "events/")
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = q.encode()
+
+	es := []OptionEvent{}
+	next := u.String()
+	for next != "" {
+		select {
+		case <-ctx.Done():
+			return es, ctx.Err()
+		default:
+		}
+
+		var out struct {
+			Results []OptionEvent
+			Pager
+		}
+		if err := c.get(ctx, next, &out); err != nil {
+			return es, err
+		}
+
+		for _, e := range out.Results {
+			if q.match(e) {
+				es = append(es, e)
+			}
+		}
+		next = out.NextURL
+	}
+
+	ois := map[string]*OptionInstrument{}
+	for i, e := range es {
+		if e.OptionURL == "" {
+			continue
+		}
+		oi, ok := ois[e.OptionURL]
+		if !ok {
+			oi, err = c.OptionInstrumentFromURL(ctx, e.OptionURL)
+			if err != nil {
+				return es, fmt.Errorf("option instrument %q: %w", e.OptionURL, err)
+			}
+			ois[e.OptionURL] = oi
+		}
+		es[i].Option = oi
+	}
+
+	return es, nil
+}
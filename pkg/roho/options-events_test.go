@@ -0,0 +1,52 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOptionEvents(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/options/events/":
+			if r.URL.Query().Get("page") == "" {
+				fmt.Fprint(w, `{"next": "https://api.robinhood.com/options/events/?page=2", "results": [
+					{"id": "e1", "type": "expiration", "event_date": "2021-08-20", "option": "https://api.robinhood.com/options/instruments/o1/", "quantity": "1.0000", "equity_components": []}
+				]}`)
+				return
+			}
+			fmt.Fprint(w, `{"next": null, "results": [
+				{"id": "e2", "type": "assignment", "event_date": "2021-09-17", "option": "https://api.robinhood.com/options/instruments/o2/", "quantity": "1.0000",
+				 "equity_components": [{"symbol": "XYZ", "side": "sell", "quantity": "100.0000", "price": "50.0000"}]}
+			]}`)
+		case "/options/instruments/o2/":
+			fmt.Fprint(w, `{"url": "https://api.robinhood.com/options/instruments/o2/", "type": "call", "strike_price": "50.0000", "expiration_date": "2021-09-17"}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	es, err := c.OptionEvents(context.Background(), OptionEventsQuery{Since: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("OptionEvents() returned error: %v", err)
+	}
+
+	if len(es) != 1 {
+		t.Fatalf("OptionEvents() returned %d events, want 1: %+v", len(es), es)
+	}
+
+	e := es[0]
+	if e.ID != "e2" || e.Type != OptionAssignment {
+		t.Errorf("OptionEvents()[0] = %+v, want assignment e2", e)
+	}
+	if e.Option == nil || e.Option.StrikePrice != 50 {
+		t.Errorf("OptionEvents()[0].Option = %+v, want strike 50", e.Option)
+	}
+	if len(e.EquityComponents) != 1 || e.EquityComponents[0].SharesDelta() != -100 {
+		t.Errorf("OptionEvents()[0].EquityComponents = %+v, want 100 shares sold", e.EquityComponents)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -43,29 +44,58 @@ type Leg struct {
 	Side           OrderSide `json:"side"`
 }
 
+// OptionLeg describes a single leg of a multi-leg options order.
+type OptionLeg struct {
+	Instrument *OptionInstrument
+	Side       OrderSide
+	// PositionEffect is "open" or "close".
+	PositionEffect string
+	// Ratio is the number of contracts of this leg per unit of the order. Default is 1.
+	Ratio float64
+}
+
 // OrderOptions places a new order for options. Cancellation of the
 // context.Context will cancel the _http request_, never the order itself if it
 // has already been created.
 func (c *Client) OrderOptions(ctx context.Context, q *OptionInstrument, o OptionsOrderOpts) (json.RawMessage, error) {
+	l := OptionLeg{Instrument: q, Side: o.Side, PositionEffect: "open"}
+	if o.Side != Buy {
+		l.PositionEffect = "close"
+	}
+	return c.OrderOptionLegs(ctx, []OptionLeg{l}, o)
+}
+
+// OrderOptionLegs places a new multi-leg order for options, such as a spread.
+// The Side of the OptionsOrderOpts is ignored in favor of the Side of each
+// leg. Cancellation of the context.Context will cancel the _http request_,
+// never the order itself if it has already been created.
+func (c *Client) OrderOptionLegs(ctx context.Context, ls []OptionLeg, o OptionsOrderOpts) (json.RawMessage, error) {
+	if len(ls) == 0 {
+		return nil, fmt.Errorf("no legs provided")
+	}
+
 	b := optionInput{
 		Account:     c.Account.URL,
 		Direction:   o.Direction,
 		TimeInForce: o.TimeInForce,
-		Legs: []Leg{{
-			Option:         q.URL,
-			RatioQuantity:  1,
-			Side:           o.Side,
-			PositionEffect: "open",
-		}},
-		Trigger:  "immediate",
-		Type:     o.Type,
-		Quantity: o.Quantity,
-		Price:    o.Price,
-		RefID:    uuid.New().String(),
+		Trigger:     "immediate",
+		Type:        o.Type,
+		Quantity:    o.Quantity,
+		Price:       o.Price,
+		RefID:       uuid.New().String(),
 	}
 
-	if o.Side != Buy {
-		b.Legs[0].PositionEffect = "close"
+	for _, l := range ls {
+		ratio := l.Ratio
+		if ratio == 0 {
+			ratio = 1
+		}
+		b.Legs = append(b.Legs, Leg{
+			Option:         l.Instrument.URL,
+			RatioQuantity:  ratio,
+			Side:           l.Side,
+			PositionEffect: l.PositionEffect,
+		})
 	}
 
 	bs, err := json.Marshal(b)
@@ -0,0 +1,52 @@
+package roho
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOrderOptionLegs(t *testing.T) {
+	var got struct {
+		Direction string
+		Price     float64 `json:"price,string"`
+		Legs      []struct {
+			Option         string
+			PositionEffect string  `json:"position_effect"`
+			RatioQuantity  float64 `json:"ratio_quantity,string"`
+			Side           string
+		}
+	}
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	ls := []OptionLeg{
+		{Instrument: &OptionInstrument{URL: "c100"}, Side: Buy, PositionEffect: "open"},
+		{Instrument: &OptionInstrument{URL: "c110"}, Side: Sell, PositionEffect: "open", Ratio: 2},
+	}
+
+	_, err := c.OrderOptionLegs(context.Background(), ls, OptionsOrderOpts{Quantity: 1, Price: 2.5, Direction: Debit, Type: Limit})
+	if err != nil {
+		t.Fatalf("OrderOptionLegs() returned error: %v", err)
+	}
+
+	want := []string{"c100 open 1 buy", "c110 open 2 sell"}
+	legs := []string{}
+	for _, l := range got.Legs {
+		legs = append(legs, fmt.Sprintf("%s %s %g %s", l.Option, l.PositionEffect, l.RatioQuantity, l.Side))
+	}
+	if diff := cmp.Diff(want, legs); diff != "" {
+		t.Errorf("unexpected legs diff: %s", diff)
+	}
+	if got.Price != 2.5 || got.Direction != "debit" {
+		t.Errorf("order = %+v, want price 2.5 and debit direction", got)
+	}
+}
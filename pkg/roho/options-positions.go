@@ -0,0 +1,265 @@
+package roho
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AssignmentRiskDays is how close to expiration an in-the-money option leg
+// must be before it is flagged as an assignment risk.
+const AssignmentRiskDays = 5
+
+// OptionHolding is an option position joined with its option instruments,
+// current market data, and the price of the underlying instrument.
+type OptionHolding struct {
+	Position *OptionPostion
+	Legs     []OptionHoldingLeg
+
+	Symbol     string
+	Strategy   string
+	Direction  string
+	Quantity   float64
+	Underlying float64
+
+	// CostBasis is what was paid to open the position, negative for credits.
+	CostBasis float64
+	// Mark is the current value of a single unit of the position.
+	Mark float64
+	// MarketValue is the current value of the whole position, negative for credits.
+	MarketValue  float64
+	UnrealizedPL float64
+
+	// DaysToExpiration is the number of days until the earliest leg expires.
+	DaysToExpiration int
+	// AssignmentRisk is true if any short leg is in the money near expiration.
+	AssignmentRisk bool
+	// Partial is true if market data is missing for any leg, in which case
+	// Mark, MarketValue and UnrealizedPL only account for the other legs.
+	Partial bool
+}
+
+// OptionHoldingLeg is a single leg of an OptionHolding.
+type OptionHoldingLeg struct {
+	Instrument *OptionInstrument
+	MarketData *MarketData
+
+	// Type is Call or Put.
+	Type string
+	// Side is "long" or "short".
+	Side             string
+	Ratio            float64
+	Strike           float64
+	Expiration       Date
+	DaysToExpiration int
+	// InTheMoney is only set if the price of the underlying instrument is known.
+	InTheMoney bool
+	// AssignmentRisk is true if the leg is short and in the money near expiration.
+	AssignmentRisk bool
+	// MarketDataErr is why MarketData is missing, if it is.
+	MarketDataErr error
+}
+
+// OptionInstrumentFromURL returns an OptionInstrument given a URL.
+func (c *Client) OptionInstrumentFromURL(ctx context.Context, url string) (*OptionInstrument, error) {
+	var oi OptionInstrument
+	if err := c.get(ctx, url, &oi); err != nil {
+		return nil, err
+	}
+	return &oi, nil
+}
+
+// OptionHoldings returns all open option positions, joined with their option
+// instruments, current market data and the price of their underlying
+// instruments. If market data is missing for some legs, the holdings are
+// returned along with a MarketDataError, and the affected legs are marked.
+func (c *Client) OptionHoldings(ctx context.Context) ([]OptionHolding, error) {
+	ps, err := c.OptionPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("option positions: %w", err)
+	}
+	if len(ps) == 0 {
+		return nil, nil
+	}
+
+	us := []string{}
+	seen := map[string]bool{}
+	syms := []string{}
+	seenSym := map[string]bool{}
+	for _, p := range ps {
+		if !seenSym[p.Symbol] {
+			seenSym[p.Symbol] = true
+			syms = append(syms, p.Symbol)
+		}
+		for _, l := range p.Legs {
+			if !seen[l.Option] {
+				seen[l.Option] = true
+				us = append(us, l.Option)
+			}
+		}
+	}
+
+	ois := make([]*OptionInstrument, len(us))
+	eg, ectx := errgroup.WithContext(ctx)
+	for i := range us {
+		// shadow for safe closure access
+		i := i
+		eg.Go(func() error {
+			oi, err := c.OptionInstrumentFromURL(ectx, us[i])
+			if err != nil {
+				return fmt.Errorf("option instrument %q: %w", us[i], err)
+			}
+			ois[i] = oi
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	insts := map[string]*OptionInstrument{}
+	for i, u := range us {
+		insts[u] = ois[i]
+	}
+
+	mds, mdErr := c.MarketDataByURL(ctx, us...)
+	var mde MarketDataError
+	if mdErr != nil && !errors.As(mdErr, &mde) {
+		return nil, fmt.Errorf("market data: %w", mdErr)
+	}
+
+	qs, err := c.Quotes(ctx, syms)
+	if err != nil {
+		return nil, fmt.Errorf("quotes: %w", err)
+	}
+
+	prices := map[string]float64{}
+	for _, q := range qs {
+		prices[q.Symbol] = q.Price()
+	}
+
+	hs := make([]OptionHolding, 0, len(ps))
+	for i := range ps {
+		h, err := newOptionHolding(&ps[i], insts, mds, prices[ps[i].Symbol], time.Now())
+		if err != nil {
+			return hs, fmt.Errorf("%s: %w", ps[i].Symbol, err)
+		}
+		for j := range h.Legs {
+			if h.Legs[j].MarketData == nil {
+				h.Legs[j].MarketDataErr = mde[h.Legs[j].Instrument.URL]
+			}
+		}
+		hs = append(hs, h)
+	}
+
+	if mde != nil {
+		return hs, mde
+	}
+	return hs, nil
+}
+
+// newOptionHolding values an option position given its option instruments and
+// market data, keyed by URL, and the price of its underlying instrument, which
+// is 0 if unknown.
+func newOptionHolding(p *OptionPostion, insts map[string]*OptionInstrument, mds map[string]*MarketData, underlying float64, now time.Time) (OptionHolding, error) {
+	h := OptionHolding{
+		Position:   p,
+		Symbol:     p.Symbol,
+		Strategy:   p.Strategy,
+		Direction:  p.Direction,
+		Underlying: underlying,
+	}
+
+	quantity, err := parseFloat(p.Quantity)
+	if err != nil {
+		return h, fmt.Errorf("quantity: %w", err)
+	}
+	h.Quantity = quantity
+
+	avg, err := parseFloat(p.AverageOpenPrice)
+	if err != nil {
+		return h, fmt.Errorf("average open price: %w", err)
+	}
+
+	multiplier, err := parseFloat(p.TradeValueMultiplier)
+	if err != nil {
+		return h, fmt.Errorf("trade value multiplier: %w", err)
+	}
+	if multiplier == 0 {
+		multiplier = 100
+	}
+
+	// average_open_price is already scaled by the trade value multiplier
+	h.CostBasis = avg * quantity
+	if p.Direction == "credit" {
+		h.CostBasis = -h.CostBasis
+	}
+
+	for i, lp := range p.Legs {
+		oi := insts[lp.Option]
+		if oi == nil {
+			return h, fmt.Errorf("leg %d: no option instrument for %q", i, lp.Option)
+		}
+
+		ratio, err := parseFloat(lp.RatioQuantity)
+		if err != nil {
+			return h, fmt.Errorf("leg %d ratio quantity: %w", i, err)
+		}
+		if ratio == 0 {
+			ratio = 1
+		}
+
+		l := OptionHoldingLeg{
+			Instrument:       oi,
+			MarketData:       mds[lp.Option],
+			Type:             oi.Type,
+			Side:             lp.PositionType,
+			Ratio:            ratio,
+			Strike:           oi.StrikePrice,
+			Expiration:       oi.ExpirationDate,
+			DaysToExpiration: oi.DaysToExpiration(now),
+		}
+
+		if underlying > 0 {
+			if l.Type == Call {
+				l.InTheMoney = underlying > l.Strike
+			} else {
+				l.InTheMoney = underlying < l.Strike
+			}
+		}
+		l.AssignmentRisk = l.Side == "short" && l.InTheMoney && l.DaysToExpiration <= AssignmentRiskDays
+
+		if i == 0 || l.DaysToExpiration < h.DaysToExpiration {
+			h.DaysToExpiration = l.DaysToExpiration
+		}
+		h.AssignmentRisk = h.AssignmentRisk || l.AssignmentRisk
+
+		if l.MarketData != nil {
+			sign := 1.0
+			if l.Side == "short" {
+				sign = -1.0
+			}
+			h.Mark += sign * ratio * l.MarketData.AdjustedMarkPrice * multiplier
+		} else {
+			h.Partial = true
+		}
+
+		h.Legs = append(h.Legs, l)
+	}
+
+	h.MarketValue = h.Mark * quantity
+	h.UnrealizedPL = h.MarketValue - h.CostBasis
+	return h, nil
+}
+
+// parseFloat parses a float from the API, treating an empty string as zero.
+func parseFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
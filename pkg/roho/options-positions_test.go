@@ -0,0 +1,114 @@
+package roho
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewOptionHolding(t *testing.T) {
+	now := time.Date(2021, 9, 14, 15, 0, 0, 0, time.UTC)
+	insts := map[string]*OptionInstrument{
+		"c100": {URL: "c100", Type: Call, StrikePrice: 100, ExpirationDate: NewZonedDate(2021, 10, 15, time.UTC)},
+		"c110": {URL: "c110", Type: Call, StrikePrice: 110, ExpirationDate: NewZonedDate(2021, 10, 15, time.UTC)},
+		"p105": {URL: "p105", Type: Put, StrikePrice: 105, ExpirationDate: NewZonedDate(2021, 9, 17, time.UTC)},
+	}
+	mds := map[string]*MarketData{
+		"c100": {Instrument: "c100", AdjustedMarkPrice: 6.00},
+		"c110": {Instrument: "c110", AdjustedMarkPrice: 1.50},
+		"p105": {Instrument: "p105", AdjustedMarkPrice: 2.00},
+	}
+
+	tests := []struct {
+		name     string
+		p        OptionPostion
+		wantMV   float64
+		wantPL   float64
+		wantDTE  int
+		wantRisk bool
+	}{
+		{
+			name: "debit call spread",
+			p: OptionPostion{
+				Symbol: "XYZ", Direction: "debit", Quantity: "2", AverageOpenPrice: "300.00", TradeValueMultiplier: "100.0000",
+				Legs: []LegPosition{
+					{Option: "c100", PositionType: "long", RatioQuantity: "1"},
+					{Option: "c110", PositionType: "short", RatioQuantity: "1"},
+				},
+			},
+			wantMV:  900,
+			wantPL:  300,
+			wantDTE: 31,
+		},
+		{
+			name: "short put near expiration",
+			p: OptionPostion{
+				Symbol: "XYZ", Direction: "credit", Quantity: "1", AverageOpenPrice: "350.00", TradeValueMultiplier: "100.0000",
+				Legs: []LegPosition{
+					{Option: "p105", PositionType: "short", RatioQuantity: "1"},
+				},
+			},
+			wantMV:   -200,
+			wantPL:   150,
+			wantDTE:  3,
+			wantRisk: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := newOptionHolding(&tc.p, insts, mds, 103, now)
+			if err != nil {
+				t.Fatalf("newOptionHolding() returned error: %v", err)
+			}
+			if fmt.Sprintf("%.2f", h.MarketValue) != fmt.Sprintf("%.2f", tc.wantMV) {
+				t.Errorf("MarketValue = %.2f, want %.2f", h.MarketValue, tc.wantMV)
+			}
+			if fmt.Sprintf("%.2f", h.UnrealizedPL) != fmt.Sprintf("%.2f", tc.wantPL) {
+				t.Errorf("UnrealizedPL = %.2f, want %.2f", h.UnrealizedPL, tc.wantPL)
+			}
+			if h.DaysToExpiration != tc.wantDTE {
+				t.Errorf("DaysToExpiration = %d, want %d", h.DaysToExpiration, tc.wantDTE)
+			}
+			if h.AssignmentRisk != tc.wantRisk {
+				t.Errorf("AssignmentRisk = %v, want %v", h.AssignmentRisk, tc.wantRisk)
+			}
+		})
+	}
+}
+
+func TestNewOptionHoldingRisk(t *testing.T) {
+	now := time.Date(2021, 9, 14, 15, 0, 0, 0, time.UTC)
+	insts := map[string]*OptionInstrument{
+		"p105": {URL: "p105", Type: Put, StrikePrice: 105, ExpirationDate: NewZonedDate(2021, 9, 17, time.UTC)},
+	}
+	long := OptionPostion{
+		Symbol: "XYZ", Direction: "debit", Quantity: "1", AverageOpenPrice: "150.00",
+		Legs: []LegPosition{{Option: "p105", PositionType: "long", RatioQuantity: "1"}},
+	}
+	short := OptionPostion{
+		Symbol: "XYZ", Direction: "credit", Quantity: "1", AverageOpenPrice: "350.00",
+		Legs: []LegPosition{{Option: "p105", PositionType: "short", RatioQuantity: "1"}},
+	}
+
+	// Long legs cannot be assigned, and there is no market data.
+	h, err := newOptionHolding(&long, insts, map[string]*MarketData{}, 103, now)
+	if err != nil {
+		t.Fatalf("newOptionHolding() returned error: %v", err)
+	}
+	if !h.Legs[0].InTheMoney || h.AssignmentRisk {
+		t.Errorf("long put: InTheMoney = %v, AssignmentRisk = %v, want true, false", h.Legs[0].InTheMoney, h.AssignmentRisk)
+	}
+	if !h.Partial {
+		t.Errorf("Partial = false without market data")
+	}
+
+	// An unknown underlying price says nothing about moneyness.
+	h, err = newOptionHolding(&short, insts, map[string]*MarketData{}, 0, now)
+	if err != nil {
+		t.Fatalf("newOptionHolding() returned error: %v", err)
+	}
+	if h.Legs[0].InTheMoney || h.AssignmentRisk {
+		t.Errorf("unknown underlying: InTheMoney = %v, AssignmentRisk = %v, want false", h.Legs[0].InTheMoney, h.AssignmentRisk)
+	}
+}
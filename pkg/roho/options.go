@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/hashicorp/go-multierror"
 )
 
 const dateFormat = "2006-01-02"
@@ -83,7 +83,8 @@ type OptionChain struct {
 }
 
 type Pager struct {
-	NextURL, PreviousURL string
+	NextURL     string `json:"next"`
+	PreviousURL string `json:"previous"`
 }
 
 func (p Pager) HasMore() bool {
@@ -98,44 +99,43 @@ func (p *Pager) Next(ctx context.Context, c *Client, out interface{}) error {
 	return c.get(ctx, p.NextURL, out)
 }
 
-// Instrument returns a list of option-typed instruments given a list of
-// expiration dates for a given trade type. The request will continue until the
-// provided context is cancelled. This is done to mimic the way the web UI
-// fetches many, many options instruments repeatedly, since I haven't yet
-// figured out how/when they decide to stop.
+// Instrument returns a list of option-typed instruments given an expiration
+// date for a given trade type. Results are paged until the API has no more to
+// return, or the provided context is cancelled.
 func (o *OptionChain) Instrument(ctx context.Context, tradeType string, date Date) ([]*OptionInstrument, error) {
-	u := fmt.Sprintf(
-		"%sinstruments/?chain_id=%s&expiration_dates=%s&state=active&tradability=tradable&type=%s",
-		baseURL("options"),
-		o.ID,
-		date,
-		tradeType,
-	)
-
-	var rs []*OptionInstrument
-	var out struct {
-		Results []*OptionInstrument
-		Pager
-	}
-	err := o.c.get(ctx, u, &out)
-	if err != nil {
-		return nil, err
+	v := url.Values{
+		"chain_id":         []string{o.ID},
+		"expiration_dates": []string{date.String()},
+		"type":             []string{tradeType},
 	}
+	return o.instruments(ctx, v)
+}
 
-	rs = append(rs, out.Results...)
+// instruments pages through the options instruments API for this chain until
+// results are exhausted or the context is cancelled.
+func (o *OptionChain) instruments(ctx context.Context, v url.Values) ([]*OptionInstrument, error) {
+	v.Set("state", "active")
+	v.Set("tradability", "tradable")
 
-	for out.HasMore() {
+	var rs []*OptionInstrument
+	u := baseURL("options") + "instruments/?" + v.Encode()
+	for u != "" {
 		select {
 		case <-ctx.Done():
 			return rs, ctx.Err()
 		default:
 		}
 
-		err := out.Next(ctx, o.c, &out)
-		if err != nil {
+		// decode each page into a fresh value so that earlier results are not reused
+		var out struct {
+			Results []*OptionInstrument
+			Pager
+		}
+		if err := o.c.get(ctx, u, &out); err != nil {
 			return rs, err
 		}
 		rs = append(rs, out.Results...)
+		u = out.NextURL
 	}
 	return rs, nil
 }
@@ -214,50 +214,114 @@ func OIsForDate(os []*OptionInstrument, d Date) []*OptionInstrument {
 	return out
 }
 
-// MarketData returns market data for all the listed Option instruments.
-func (c *Client) MarketData(ctx context.Context, opts ...*OptionInstrument) ([]*MarketData, error) {
-	is := make([]string, len(opts))
+// marketDataBatchSize is the number of instruments to request market data for at once.
+const marketDataBatchSize = 30
+
+// marketDataConcurrency is the maximum number of market data requests in flight at once.
+const marketDataConcurrency = 4
+
+// MarketDataError reports the option instruments for which market data could
+// not be retrieved, keyed by instrument URL.
+type MarketDataError map[string]error
+
+func (e MarketDataError) Error() string {
+	us := make([]string, 0, len(e))
+	for u := range e {
+		us = append(us, u)
+	}
+	sort.Strings(us)
+
+	es := make([]string, 0, len(us))
+	for _, u := range us {
+		es = append(es, fmt.Sprintf("%s: %v", u, e[u]))
+	}
+	return fmt.Sprintf("market data failed for %d instruments: %s", len(e), strings.Join(es, ", "))
+}
 
+// MarketData returns market data for all the listed Option instruments, keyed
+// by instrument URL. If some instruments fail, the data that could be
+// retrieved is returned along with a MarketDataError.
+func (c *Client) MarketData(ctx context.Context, opts ...*OptionInstrument) (map[string]*MarketData, error) {
+	us := make([]string, len(opts))
 	for i, o := range opts {
-		is[i] = o.URL
+		us[i] = o.URL
 	}
+	return c.MarketDataByURL(ctx, us...)
+}
 
-	u, err := url.Parse(baseURL("options"))
-	if err != nil {
-		return nil, fmt.Errorf("parse: %w", err)
+// MarketDataByURL returns market data for a list of option instrument URLs,
+// keyed by instrument URL. If some instruments fail, the data that could be
+// retrieved is returned along with a MarketDataError.
+func (c *Client) MarketDataByURL(ctx context.Context, urls ...string) (map[string]*MarketData, error) {
+	seen := map[string]bool{}
+	us := []string{}
+	for _, u := range urls {
+		if !seen[u] {
+			seen[u] = true
+			us = append(us, u)
+		}
 	}
 
-	// Number of instruments to request at once
-	num := 30
-	// Number of requests this will require to be made
-	n := len(is) / num
-	if len(is)%num != 0 {
-		n++
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, marketDataConcurrency)
+
+	rs := map[string]*MarketData{}
+	errs := MarketDataError{}
+
+	for _, ck := range chunkStrings(us, marketDataBatchSize) {
+		ck := ck
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mds, err := c.marketDataChunk(ctx, ck)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, u := range ck {
+				switch {
+				case err != nil:
+					errs[u] = err
+				case mds[u] == nil:
+					errs[u] = fmt.Errorf("no market data returned")
+				default:
+					rs[u] = mds[u]
+				}
+			}
+		}()
 	}
 
-	rs := []*MarketData{}
+	wg.Wait()
 
-	for i := 0; i < n; i++ {
-		end := (i+1)*num + 1
-		if end > len(is) {
-			end = len(is)
-		}
+	if len(errs) > 0 {
+		return rs, errs
+	}
+	return rs, nil
+}
 
-		q := url.Values{"instruments": []string{strings.Join(is[i*num:end], ",")}}
+// marketDataChunk fetches market data for a single batch of instrument URLs.
+func (c *Client) marketDataChunk(ctx context.Context, us []string) (map[string]*MarketData, error) {
+	q := url.Values{"instruments": []string{strings.Join(us, ",")}}
 
-		u.RawQuery = q.Encode()
+	var r struct{ Results []*MarketData }
+	if err := c.get(ctx, baseURL("marketdata/options")+"?"+q.Encode(), &r); err != nil {
+		return nil, err
+	}
 
-		var r struct{ Results []*MarketData }
-		if e := c.get(ctx, u.String(), &r); err != nil {
-			err = multierror.Append(err, e)
+	mds := map[string]*MarketData{}
+	for i, md := range r.Results {
+		if md == nil {
 			continue
 		}
-		for _, res := range r.Results {
-			if res != nil {
-				rs = append(rs, res)
-			}
+		mds[md.Instrument] = md
+		// Results are returned in request order, with null entries for unknown instruments.
+		if len(r.Results) == len(us) {
+			mds[us[i]] = md
 		}
 	}
-
-	return rs, err
+	return mds, nil
 }
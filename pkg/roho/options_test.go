@@ -2,8 +2,13 @@ package roho
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -54,3 +59,65 @@ func TestMarketData(t *testing.T) {
 
 	fmt.Printf("len(is) = %+v\n", len(is))
 }
+
+func TestMarketDataByURL(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		us := strings.Split(r.URL.Query().Get("instruments"), ",")
+		rs := []*MarketData{}
+		for _, u := range us {
+			switch u {
+			case "missing":
+				rs = append(rs, nil)
+			case "fail":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"detail": "bad instrument"}`)
+				return
+			default:
+				rs = append(rs, &MarketData{Instrument: u, MarkPrice: 1})
+			}
+		}
+		json.NewEncoder(w).Encode(struct{ Results []*MarketData }{rs})
+	})
+
+	us := []string{"missing", "missing"}
+	for i := 0; i < 65; i++ {
+		us = append(us, fmt.Sprintf("u%d", i))
+	}
+
+	got, err := c.MarketDataByURL(context.Background(), us...)
+	var mde MarketDataError
+	if !errors.As(err, &mde) {
+		t.Fatalf("MarketDataByURL() error = %v, want MarketDataError", err)
+	}
+	if len(mde) != 1 || mde["missing"] == nil {
+		t.Errorf("MarketDataByURL() errors = %v, want only %q", mde, "missing")
+	}
+	if len(got) != 65 {
+		t.Errorf("MarketDataByURL() returned %d results, want 65", len(got))
+	}
+	for i := 0; i < 65; i++ {
+		u := fmt.Sprintf("u%d", i)
+		if got[u] == nil || got[u].Instrument != u {
+			t.Errorf("MarketDataByURL()[%q] = %+v", u, got[u])
+		}
+	}
+	// 66 unique URLs in batches of 30
+	if calls != 3 {
+		t.Errorf("MarketDataByURL() made %d calls, want 3", calls)
+	}
+
+	got, err = c.MarketDataByURL(context.Background(), "u1", "fail")
+	if !errors.As(err, &mde) || len(mde) != 2 {
+		t.Errorf("MarketDataByURL() error = %v, want MarketDataError for both instruments", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("MarketDataByURL() returned %d results, want 0", len(got))
+	}
+}
@@ -47,16 +47,59 @@ type LegPosition struct {
 	OptionType     string `json:"option_type"`
 }
 
+// CryptoPosition is a holding of a crypto currency.
 type CryptoPosition struct {
 	Meta
-	Id                  string  `json:"id"`
-	AccountId           string  `json:"account_id"`
-	Quantity            float64 `json:"quantity"`
-	QuantityAvailable   float64 `json:"quantity_avalaible"`
-	Currency            string  `json:"currency"`
-	CostBasis           float64 `json:"cost_basis"`
-	QuantityHeldForBuy  float64 `json:"quantity_held_for_buy"`
-	QuantityHeldForSell float64 `json:"quantity_held_for_sell"`
+	Id                  string            `json:"id"`
+	AccountId           string            `json:"account_id"`
+	Quantity            float64           `json:"quantity,string"`
+	QuantityAvailable   float64           `json:"quantity_available,string"`
+	Currency            CryptoCurrency    `json:"currency"`
+	CostBases           []CryptoCostBasis `json:"cost_bases"`
+	QuantityHeldForBuy  float64           `json:"quantity_held_for_buy,string"`
+	QuantityHeldForSell float64           `json:"quantity_held_for_sell,string"`
+}
+
+// CryptoCurrency identifies the crypto currency of a CryptoPosition.
+type CryptoCurrency struct {
+	Code      string  `json:"code"`
+	ID        string  `json:"id"`
+	Increment float64 `json:"increment,string"`
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+}
+
+// CryptoCostBasis is the amount paid for a crypto currency holding.
+type CryptoCostBasis struct {
+	ID                string  `json:"id"`
+	CurrencyID        string  `json:"currency_id"`
+	DirectCostBasis   float64 `json:"direct_cost_basis,string"`
+	DirectQuantity    float64 `json:"direct_quantity,string"`
+	IntradayCostBasis float64 `json:"intraday_cost_basis,string"`
+	IntradayQuantity  float64 `json:"intraday_quantity,string"`
+	MarkedCostBasis   float64 `json:"marked_cost_basis,string"`
+	MarkedQuantity    float64 `json:"marked_quantity,string"`
+}
+
+// CostBasis returns the total amount paid for the position.
+func (p CryptoPosition) CostBasis() float64 {
+	var total float64
+	for _, cb := range p.CostBases {
+		total += cb.DirectCostBasis
+	}
+	return total
+}
+
+// AverageCost returns the average price paid per unit of crypto currency held.
+func (p CryptoPosition) AverageCost() float64 {
+	var quantity float64
+	for _, cb := range p.CostBases {
+		quantity += cb.DirectQuantity
+	}
+	if quantity == 0 {
+		return 0
+	}
+	return p.CostBasis() / quantity
 }
 
 type Unknown interface{}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tstromberg/roho/pkg/times"
 )
@@ -11,44 +12,112 @@ import (
 // A Quote is a representation of the data returned by the Robinhood API for
 // current stock quotes.
 type Quote struct {
-	AdjustedPreviousClose       float64 `json:"adjusted_previous_close,string"`
-	AskPrice                    float64 `json:"ask_price,string"`
-	AskSize                     int     `json:"ask_size"`
-	BidPrice                    float64 `json:"bid_price,string"`
-	BidSize                     int     `json:"bid_size"`
-	LastExtendedHoursTradePrice float64 `json:"last_extended_hours_trade_price,string"`
-	LastTradePrice              float64 `json:"last_trade_price,string"`
-	PreviousClose               float64 `json:"previous_close,string"`
-	PreviousCloseDate           string  `json:"previous_close_date"`
-	Symbol                      string  `json:"symbol"`
-	TradingHalted               bool    `json:"trading_halted"`
-	UpdatedAt                   string  `json:"updated_at"`
-	InstrumentURL               string  `json:"instrument"`
-	InstrumentID                string  `json:"instrument_id"`
+	AdjustedPreviousClose       float64   `json:"adjusted_previous_close,string"`
+	AskPrice                    float64   `json:"ask_price,string"`
+	AskSize                     int       `json:"ask_size"`
+	BidPrice                    float64   `json:"bid_price,string"`
+	BidSize                     int       `json:"bid_size"`
+	LastExtendedHoursTradePrice float64   `json:"last_extended_hours_trade_price,string"`
+	LastTradePrice              float64   `json:"last_trade_price,string"`
+	PreviousClose               float64   `json:"previous_close,string"`
+	PreviousCloseDate           string    `json:"previous_close_date"`
+	Symbol                      string    `json:"symbol"`
+	TradingHalted               bool      `json:"trading_halted"`
+	HasTraded                   bool      `json:"has_traded"`
+	UpdatedAt                   time.Time `json:"updated_at"`
+	InstrumentURL               string    `json:"instrument"`
+	InstrumentID                string    `json:"instrument_id"`
 }
 
+// Trading sessions, as found in HistoricalRecord.Session and returned by
+// Quote.Session.
+const (
+	SessionPre     = "pre"
+	SessionRegular = "reg"
+	SessionPost    = "post"
+	SessionClosed  = "closed"
+)
+
 // Quote returns the latest stock quote for a symbol.
 func (c *Client) Quote(ctx context.Context, symbol string) (Quote, error) {
 	qs, err := c.Quotes(ctx, []string{symbol})
-	return qs[0], err
+	if err != nil {
+		return Quote{}, err
+	}
+
+	for _, q := range qs {
+		if strings.EqualFold(q.Symbol, symbol) {
+			return q, nil
+		}
+	}
+	return Quote{}, fmt.Errorf("no quote for %q", symbol)
 }
 
-// Quote returns the latest stock quotes for the symbols provided.
+// maxQuoteSymbols is the number of symbols requested per quotes API call.
+const maxQuoteSymbols = 75
+
+// Quotes returns the latest stock quotes for the symbols provided, fetching
+// them in batches of maxQuoteSymbols.
 func (c *Client) Quotes(ctx context.Context, symbols []string) ([]Quote, error) {
 	if len(symbols) == 0 {
 		return nil, fmt.Errorf("0 symbols provided")
 	}
 
-	url := baseURL("quotes") + "?symbols=" + strings.Join(symbols, ",")
-	var r struct{ Results []Quote }
-	err := c.get(ctx, url, &r)
-	return r.Results, err
+	qs := []Quote{}
+	for _, ck := range chunkStrings(symbols, maxQuoteSymbols) {
+		url := baseURL("quotes") + "?symbols=" + strings.Join(ck, ",")
+		var r struct{ Results []Quote }
+		if err := c.get(ctx, url, &r); err != nil {
+			return qs, err
+		}
+		qs = append(qs, r.Results...)
+	}
+	return qs, nil
 }
 
 // Price returns the proper stock price even after hours.
 func (q Quote) Price() float64 {
-	if times.IsRegularTradingTime() {
+	if times.IsRegularTradingTime() || q.LastExtendedHoursTradePrice == 0 {
 		return q.LastTradePrice
 	}
 	return q.LastExtendedHoursTradePrice
 }
+
+// Age returns how long ago the quote was last updated.
+func (q Quote) Age(now time.Time) time.Duration {
+	return now.Sub(q.UpdatedAt)
+}
+
+// IsStale returns whether the quote was last updated more than maxAge ago, or
+// has no update time at all.
+func (q Quote) IsStale(now time.Time, maxAge time.Duration) bool {
+	return q.UpdatedAt.IsZero() || q.Age(now) > maxAge
+}
+
+// Session returns the trading session in which the quote was last updated.
+func (q Quote) Session() string {
+	return SessionAt(q.UpdatedAt)
+}
+
+// SessionAt returns the trading session a point in time falls within,
+// according to the market hours of its day.
+func SessionAt(t time.Time) string {
+	h := times.HoursOn(t)
+	if !h.IsOpen {
+		return SessionClosed
+	}
+
+	at := func(m int) time.Time { return h.Date.Add(time.Duration(m) * time.Minute) }
+	switch {
+	case t.Before(at(times.MinExtendedOpen)):
+		return SessionClosed
+	case t.Before(h.Opens):
+		return SessionPre
+	case t.Before(h.Closes):
+		return SessionRegular
+	case t.Before(at(times.MinExtendedClose)):
+		return SessionPost
+	default:
+		return SessionClosed
+	}
+}
@@ -0,0 +1,214 @@
+package roho
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+	"k8s.io/klog/v2"
+)
+
+// MinQuoteInterval is the shortest interval a QuoteHub polls at, so that a
+// zero or tiny interval does not hammer the quotes API.
+const MinQuoteInterval = time.Second
+
+// ErrSlowConsumer is returned by QuoteSubscription.Err if the subscription was
+// dropped because it did not keep up with quote updates.
+var ErrSlowConsumer = errors.New("quote subscriber is not keeping up")
+
+// QuoteHub polls for quotes of every subscribed symbol on a shared interval,
+// and fans them out to each subscriber, so that many consumers of quotes cost
+// no more API calls than one.
+type QuoteHub struct {
+	// Interval is how often quotes are polled while equities are trading,
+	// including extended hours.
+	Interval time.Duration
+	// ClosedInterval is how often quotes are polled while the markets are closed.
+	ClosedInterval time.Duration
+
+	c    *Client
+	mu   sync.Mutex
+	subs map[*QuoteSubscription]bool
+}
+
+// NewQuoteHub returns a QuoteHub which polls for quotes every interval while
+// the markets are open, but no more often than MinQuoteInterval. Quotes are not
+// polled until Run or Poll is called.
+func (c *Client) NewQuoteHub(interval time.Duration) *QuoteHub {
+	if interval < MinQuoteInterval {
+		interval = MinQuoteInterval
+	}
+	return &QuoteHub{
+		Interval:       interval,
+		ClosedInterval: 10 * interval,
+		c:              c,
+		subs:           map[*QuoteSubscription]bool{},
+	}
+}
+
+// QuoteSubscription is a stream of quotes for a set of symbols.
+type QuoteSubscription struct {
+	// C receives quote updates, and is closed when the subscription ends.
+	C <-chan Quote
+
+	ch      chan Quote
+	symbols map[string]bool
+	hub     *QuoteHub
+	err     error
+}
+
+// Subscribe returns a subscription to quotes for the symbols. Up to buffer
+// quotes are queued for the subscriber; if it falls further behind, the
+// subscription is dropped rather than delaying other subscribers.
+func (h *QuoteHub) Subscribe(symbols []string, buffer int) *QuoteSubscription {
+	if buffer < len(symbols) {
+		buffer = len(symbols)
+	}
+
+	ch := make(chan Quote, buffer)
+	s := &QuoteSubscription{C: ch, ch: ch, symbols: map[string]bool{}, hub: h}
+	for _, sym := range symbols {
+		s.symbols[strings.ToUpper(sym)] = true
+	}
+
+	h.mu.Lock()
+	h.subs[s] = true
+	h.mu.Unlock()
+	return s
+}
+
+// Close ends the subscription.
+func (s *QuoteSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.drop(s, nil)
+}
+
+// Err returns why the subscription ended, if it was ended by the hub.
+func (s *QuoteSubscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// drop ends a subscription. The caller must hold h.mu.
+func (h *QuoteHub) drop(s *QuoteSubscription, err error) {
+	if !h.subs[s] {
+		return
+	}
+	delete(h.subs, s)
+	s.err = err
+	close(s.ch)
+}
+
+// symbols returns the sorted union of all subscribed symbols.
+func (h *QuoteHub) symbols() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seen := map[string]bool{}
+	for s := range h.subs {
+		for sym := range s.symbols {
+			seen[sym] = true
+		}
+	}
+
+	syms := []string{}
+	for sym := range seen {
+		syms = append(syms, sym)
+	}
+	sort.Strings(syms)
+	return syms
+}
+
+// Poll fetches quotes for all subscribed symbols once, and delivers them to
+// subscribers. A failure to fetch one batch of symbols does not prevent the
+// others from being delivered; the failures are returned together.
+func (h *QuoteHub) Poll(ctx context.Context) error {
+	syms := h.symbols()
+	if len(syms) == 0 {
+		return nil
+	}
+
+	chunks := chunkStrings(syms, maxQuoteSymbols)
+	errs := []string{}
+	for _, ck := range chunks {
+		qs, err := h.c.Quotes(ctx, ck)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s-%s: %v", ck[0], ck[len(ck)-1], err))
+			continue
+		}
+		h.publish(qs)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d quote requests failed: %s", len(errs), len(chunks), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// publish delivers quotes to the subscribers of their symbols, dropping
+// subscribers whose buffers are full.
+func (h *QuoteHub) publish(qs []Quote) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, q := range qs {
+		sym := strings.ToUpper(q.Symbol)
+		for s := range h.subs {
+			if !s.symbols[sym] {
+				continue
+			}
+			select {
+			case s.ch <- q:
+			default:
+				klog.Warningf("dropping quote subscriber for %d symbols: %v", len(s.symbols), ErrSlowConsumer)
+				h.drop(s, ErrSlowConsumer)
+			}
+		}
+	}
+}
+
+// interval returns how long to wait between polls at the current time.
+func (h *QuoteHub) interval() time.Duration {
+	d := h.ClosedInterval
+	if times.IsWeekDay(time.Now()) && times.IsExtendedTradingTime() {
+		d = h.Interval
+	}
+	if d < MinQuoteInterval {
+		return MinQuoteInterval
+	}
+	return d
+}
+
+// Run polls for quotes until the context is cancelled, at which point all
+// subscriptions are closed. Poll failures are logged and retried on the next
+// interval.
+func (h *QuoteHub) Run(ctx context.Context) error {
+	defer func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for s := range h.subs {
+			h.drop(s, ctx.Err())
+		}
+	}()
+
+	for {
+		if err := h.Poll(ctx); err != nil {
+			klog.Warningf("quote poll failed: %v", err)
+		}
+
+		t := time.NewTimer(h.interval())
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestQuoteHub(t *testing.T) {
+	calls := 0
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/quotes/" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		rs := []string{}
+		for _, sym := range strings.Split(r.URL.Query().Get("symbols"), ",") {
+			rs = append(rs, fmt.Sprintf(`{"symbol": %q, "last_trade_price": "%d.00"}`, sym, calls))
+		}
+		fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(rs, ","))
+	})
+
+	h := c.NewQuoteHub(0)
+	if h.Interval != MinQuoteInterval || h.interval() < MinQuoteInterval {
+		t.Errorf("NewQuoteHub(0) polls every %s, want at least %s", h.interval(), MinQuoteInterval)
+	}
+	a := h.Subscribe([]string{"aapl", "MSFT"}, 10)
+	b := h.Subscribe([]string{"MSFT"}, 1)
+	ctx := context.Background()
+
+	if err := h.Poll(ctx); err != nil {
+		t.Fatalf("Poll() returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Poll() made %d calls, want 1", calls)
+	}
+
+	got := []string{}
+	for i := 0; i < 2; i++ {
+		q := <-a.C
+		got = append(got, fmt.Sprintf("%s=%.0f", q.Symbol, q.LastTradePrice))
+	}
+	if strings.Join(got, " ") != "AAPL=1 MSFT=1" {
+		t.Errorf("first subscriber got %v, want [AAPL=1 MSFT=1]", got)
+	}
+
+	// b has not consumed its only buffered quote, so it is dropped on the next poll.
+	if err := h.Poll(ctx); err != nil {
+		t.Fatalf("Poll() returned error: %v", err)
+	}
+	if err := b.Err(); err != ErrSlowConsumer {
+		t.Errorf("slow subscriber Err() = %v, want %v", err, ErrSlowConsumer)
+	}
+	n := 0
+	for range b.C {
+		n++
+	}
+	if n != 1 {
+		t.Errorf("slow subscriber received %d quotes before being dropped, want 1", n)
+	}
+
+	a.Close()
+	if _, ok := <-a.C; !ok {
+		t.Errorf("closed subscription did not drain buffered quotes")
+	}
+	if got := h.symbols(); len(got) != 0 {
+		t.Errorf("symbols() after all subscriptions ended = %v, want none", got)
+	}
+}
+
+func TestQuoteHubPartialFailure(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		syms := strings.Split(r.URL.Query().Get("symbols"), ",")
+		// The first batch of symbols always fails.
+		if syms[0] == "A000" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rs := []string{}
+		for _, sym := range syms {
+			rs = append(rs, fmt.Sprintf(`{"symbol": %q}`, sym))
+		}
+		fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(rs, ","))
+	})
+
+	h := c.NewQuoteHub(0)
+	syms := []string{}
+	for i := 0; i < maxQuoteSymbols; i++ {
+		syms = append(syms, fmt.Sprintf("A%03d", i))
+	}
+	h.Subscribe(syms, 0)
+	later := h.Subscribe([]string{"ZZZ"}, 1)
+
+	if err := h.Poll(context.Background()); err == nil {
+		t.Errorf("Poll() returned nil error with a failing batch")
+	}
+	select {
+	case q := <-later.C:
+		if q.Symbol != "ZZZ" {
+			t.Errorf("later subscriber got %q, want ZZZ", q.Symbol)
+		}
+	default:
+		t.Errorf("later subscriber got no quote")
+	}
+}
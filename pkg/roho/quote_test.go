@@ -0,0 +1,91 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+func TestQuoteSession(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2021, 9, 14, 7, 0, 0, 0, time.UTC), SessionClosed},
+		{time.Date(2021, 9, 14, 12, 0, 0, 0, time.UTC), SessionPre},
+		{time.Date(2021, 9, 14, 13, 30, 0, 0, time.UTC), SessionRegular},
+		{time.Date(2021, 9, 14, 21, 0, 0, 0, time.UTC), SessionPost},
+		// Saturday
+		{time.Date(2021, 9, 18, 15, 0, 0, 0, time.UTC), SessionClosed},
+	}
+
+	for _, tc := range tests {
+		q := Quote{UpdatedAt: tc.t}
+		if got := q.Session(); got != tc.want {
+			t.Errorf("Session() at %s = %q, want %q", tc.t, got, tc.want)
+		}
+	}
+
+	// Thanksgiving
+	thanksgiving := time.Date(2021, 11, 25, 15, 0, 0, 0, time.UTC)
+	times.SetHoursSource(holidays{"2021-11-25": true})
+	defer times.SetHoursSource(nil)
+	if got := SessionAt(thanksgiving); got != SessionClosed {
+		t.Errorf("SessionAt(%s) = %q, want %q", thanksgiving, got, SessionClosed)
+	}
+
+	q := Quote{UpdatedAt: tests[2].t}
+	if q.IsStale(tests[2].t.Add(time.Minute), 5*time.Minute) {
+		t.Errorf("IsStale() = true for a minute old quote")
+	}
+	if !q.IsStale(tests[2].t.Add(time.Hour), 5*time.Minute) {
+		t.Errorf("IsStale() = false for an hour old quote")
+	}
+	if !(Quote{}).IsStale(tests[2].t, time.Hour) {
+		t.Errorf("IsStale() = false for a quote with no update time")
+	}
+}
+
+func TestQuoteMissing(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"results": []}`)
+	})
+
+	if _, err := c.Quote(context.Background(), "NOPE"); err == nil {
+		t.Errorf("Quote() of a missing symbol returned nil error")
+	}
+}
+
+func TestQuotesChunked(t *testing.T) {
+	calls := 0
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		syms := strings.Split(r.URL.Query().Get("symbols"), ",")
+		if len(syms) > maxQuoteSymbols {
+			t.Errorf("requested %d symbols, want at most %d", len(syms), maxQuoteSymbols)
+		}
+		qs := []string{}
+		for _, s := range syms {
+			qs = append(qs, fmt.Sprintf(`{"symbol": %q}`, s))
+		}
+		fmt.Fprintf(w, `{"results": [%s]}`, strings.Join(qs, ","))
+	})
+
+	syms := []string{}
+	for i := 0; i < maxQuoteSymbols+1; i++ {
+		syms = append(syms, fmt.Sprintf("S%d", i))
+	}
+
+	qs, err := c.Quotes(context.Background(), syms)
+	if err != nil {
+		t.Fatalf("Quotes() returned error: %v", err)
+	}
+	if len(qs) != len(syms) || calls != 2 {
+		t.Errorf("Quotes() returned %d quotes in %d calls, want %d in 2", len(qs), calls, len(syms))
+	}
+}
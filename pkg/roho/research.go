@@ -0,0 +1,88 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NewsItem is a news story about one or more instruments.
+type NewsItem struct {
+	UUID               string    `json:"uuid"`
+	Title              string    `json:"title"`
+	Source             string    `json:"source"`
+	Author             string    `json:"author"`
+	Summary            string    `json:"summary"`
+	PreviewText        string    `json:"preview_text"`
+	URL                string    `json:"url"`
+	PublishedAt        time.Time `json:"published_at"`
+	RelatedInstruments []string  `json:"related_instruments"`
+}
+
+// News returns recent news stories about a stock symbol, newest first.
+func (c *Client) News(ctx context.Context, symbol string) ([]NewsItem, error) {
+	var r struct{ Results []NewsItem }
+	if err := c.get(ctx, baseURL("midlands/news/"+url.PathEscape(strings.ToUpper(symbol))), &r); err != nil {
+		return nil, fmt.Errorf("news: %w", err)
+	}
+	return r.Results, nil
+}
+
+// Types of analyst rating.
+const (
+	RatingBuy  = "buy"
+	RatingHold = "hold"
+	RatingSell = "sell"
+)
+
+// Rating is a single analyst rating summary.
+type Rating struct {
+	PublishedAt time.Time `json:"published_at"`
+	// Type is RatingBuy, RatingHold or RatingSell.
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// RatingsSummary counts analyst ratings by type.
+type RatingsSummary struct {
+	Buy  int `json:"num_buy_ratings"`
+	Hold int `json:"num_hold_ratings"`
+	Sell int `json:"num_sell_ratings"`
+}
+
+// Total returns the number of analyst ratings.
+func (s RatingsSummary) Total() int {
+	return s.Buy + s.Hold + s.Sell
+}
+
+// Score returns the balance of analyst opinion, from -1 (all sell ratings) to
+// 1 (all buy ratings), or 0 if there are no ratings.
+func (s RatingsSummary) Score() float64 {
+	if s.Total() == 0 {
+		return 0
+	}
+	return float64(s.Buy-s.Sell) / float64(s.Total())
+}
+
+func (s RatingsSummary) String() string {
+	return fmt.Sprintf("%d buy, %d hold, %d sell", s.Buy, s.Hold, s.Sell)
+}
+
+// Ratings are the analyst ratings of an instrument.
+type Ratings struct {
+	InstrumentID string         `json:"instrument_id"`
+	Summary      RatingsSummary `json:"summary"`
+	Ratings      []Rating       `json:"ratings"`
+	PublishedAt  time.Time      `json:"ratings_published_at"`
+}
+
+// Ratings returns the analyst ratings of an instrument.
+func (c *Client) Ratings(ctx context.Context, i *Instrument) (*Ratings, error) {
+	var r Ratings
+	if err := c.get(ctx, baseURL("midlands/ratings/"+i.ID), &r); err != nil {
+		return nil, fmt.Errorf("ratings: %w", err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,45 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestResearch(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/midlands/news/AAPL/":
+			fmt.Fprint(w, `{"next": null, "results": [
+				{"uuid": "n1", "title": "Apple unveils thing", "source": "Wire", "url": "https://example.com/n1", "published_at": "2021-09-14T17:00:00Z", "related_instruments": ["i1"]}
+			]}`)
+		case "/midlands/ratings/i1/":
+			fmt.Fprint(w, `{"instrument_id": "i1", "summary": {"num_buy_ratings": 6, "num_hold_ratings": 2, "num_sell_ratings": 2},
+				"ratings": [{"published_at": "2021-09-01T00:00:00Z", "type": "buy", "text": "Strong ecosystem"}], "ratings_published_at": "2021-09-01T00:00:00Z"}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ns, err := c.News(ctx, "aapl")
+	if err != nil {
+		t.Fatalf("News() returned error: %v", err)
+	}
+	if len(ns) != 1 || ns[0].Title != "Apple unveils thing" || ns[0].PublishedAt.IsZero() {
+		t.Errorf("News() = %+v", ns)
+	}
+
+	rs, err := c.Ratings(ctx, &Instrument{ID: "i1"})
+	if err != nil {
+		t.Fatalf("Ratings() returned error: %v", err)
+	}
+	if rs.Summary.Total() != 10 || rs.Summary.Score() != 0.4 || rs.Ratings[0].Type != RatingBuy {
+		t.Errorf("Ratings() = %+v", rs)
+	}
+	if got := rs.Summary.String(); got != "6 buy, 2 hold, 2 sell" {
+		t.Errorf("Summary.String() = %q", got)
+	}
+}
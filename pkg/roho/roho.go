@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"golang.org/x/oauth2"
+	"k8s.io/klog/v2"
 )
 
 type Config struct {
@@ -31,7 +32,21 @@ func New(ctx context.Context, c *Config) (*Client, error) {
 		return nil, fmt.Errorf("token: %w", err)
 	}
 
-	return Dial(ctx, oauth2.StaticTokenSource(token))
+	rc, err := Dial(ctx, oauth2.StaticTokenSource(token))
+	if err != nil {
+		return nil, err
+	}
+
+	// Instruments rarely change, so they are cached across runs.
+	if p, err := DefaultInstrumentCachePath(); err == nil {
+		ic, err := NewInstrumentCache(p, DefaultInstrumentTTL)
+		if err != nil {
+			klog.Warningf("unable to load instrument cache: %v", err)
+		} else {
+			rc.InstrumentCache = ic
+		}
+	}
+	return rc, nil
 }
 
 // A Client is a helpful abstraction around some common metadata required for
@@ -40,14 +55,24 @@ type Client struct {
 	Token         string
 	Account       *Account
 	CryptoAccount *CryptoAccount
+	// InstrumentCache, if set, caches instrument lookups.
+	InstrumentCache *InstrumentCache
 	*http.Client
+
+	hours hoursCache
 }
 
 // Dial returns a client given a TokenGetter. TokenGetter implementations are
 // available in this package, including a Cookie-based cache.
 func Dial(ctx context.Context, s oauth2.TokenSource) (*Client, error) {
+	ic, err := NewInstrumentCache("", DefaultInstrumentTTL)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
-		Client: oauth2.NewClient(context.Background(), s),
+		Client:          oauth2.NewClient(context.Background(), s),
+		InstrumentCache: ic,
 	}
 
 	a, err := c.Accounts(ctx)
@@ -0,0 +1,216 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/times"
+)
+
+// Split is a stock split (or reverse split) of an instrument.
+type Split struct {
+	URL           string  `json:"url"`
+	Instrument    string  `json:"instrument"`
+	ExecutionDate Date    `json:"execution_date"`
+	Multiplier    float64 `json:"multiplier,string"`
+	Divisor       float64 `json:"divisor,string"`
+}
+
+// Ratio returns the number of shares held after the split for each share held
+// before it: 4 for a 4-for-1 split, and 0.1 for a 1-for-10 reverse split.
+func (s Split) Ratio() float64 {
+	if s.Divisor == 0 {
+		return 1
+	}
+	return s.Multiplier / s.Divisor
+}
+
+// Splits returns the splits of an instrument, oldest first.
+func (c *Client) Splits(ctx context.Context, i *Instrument) ([]Split, error) {
+	next := i.Splits
+	if next == "" {
+		next = baseURL("instruments") + i.ID + "/splits/"
+	}
+
+	ss := []Split{}
+	for next != "" {
+		select {
+		case <-ctx.Done():
+			return ss, ctx.Err()
+		default:
+		}
+
+		var out struct {
+			Results []Split
+			Pager
+		}
+		if err := c.get(ctx, next, &out); err != nil {
+			return ss, fmt.Errorf("splits: %w", err)
+		}
+		ss = append(ss, out.Results...)
+		next = out.NextURL
+	}
+
+	sort.Slice(ss, func(i, j int) bool { return ss[i].ExecutionDate.Before(ss[j].ExecutionDate.Time) })
+	return ss, nil
+}
+
+// Dividend is a cash dividend paid, or to be paid, to the account.
+type Dividend struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Account     string    `json:"account"`
+	Instrument  string    `json:"instrument"`
+	Amount      float64   `json:"amount,string"`
+	Rate        float64   `json:"rate,string"`
+	Position    float64   `json:"position,string"`
+	Withholding float64   `json:"withholding,string"`
+	RecordDate  Date      `json:"record_date"`
+	PayableDate Date      `json:"payable_date"`
+	PaidAt      time.Time `json:"paid_at"`
+	State       string    `json:"state"`
+}
+
+// t1Settlement is when US equities moved from T+2 to T+1 settlement.
+var t1Settlement = time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC)
+
+// ExDate returns the first date on which the stock trades without the
+// dividend, which the API does not provide. With T+1 settlement this is the
+// record date, and with the earlier T+2 settlement it was the weekday before.
+func (d Dividend) ExDate() Date {
+	if !d.RecordDate.Before(t1Settlement) {
+		return d.RecordDate
+	}
+
+	ex := d.RecordDate.AddDate(0, 0, -1)
+	for !times.IsWeekDay(ex) {
+		ex = ex.AddDate(0, 0, -1)
+	}
+	return Date{ex}
+}
+
+// Dividends returns the dividends paid to the account, optionally limited to
+// an instrument URL. Robinhood only reports dividends the account received, so
+// there is no dividend history for instruments the account never held.
+func (c *Client) Dividends(ctx context.Context, instrumentURL string) ([]Dividend, error) {
+	ds := []Dividend{}
+	next := baseURL("dividends")
+	for next != "" {
+		select {
+		case <-ctx.Done():
+			return ds, ctx.Err()
+		default:
+		}
+
+		var out struct {
+			Results []Dividend
+			Pager
+		}
+		if err := c.get(ctx, next, &out); err != nil {
+			return ds, fmt.Errorf("dividends: %w", err)
+		}
+		for _, d := range out.Results {
+			if instrumentURL == "" || d.Instrument == instrumentURL {
+				ds = append(ds, d)
+			}
+		}
+		next = out.NextURL
+	}
+
+	sort.Slice(ds, func(i, j int) bool { return ds[i].RecordDate.Before(ds[j].RecordDate.Time) })
+	return ds, nil
+}
+
+// AdjustForSplits returns a copy of rs in which the prices and volumes of
+// records before each split are restated in post-split shares, so that splits
+// do not appear as sudden price changes.
+func AdjustForSplits(rs []HistoricalRecord, ss []Split) []HistoricalRecord {
+	out := make([]HistoricalRecord, len(rs))
+	copy(out, rs)
+
+	for _, s := range ss {
+		ratio := s.Ratio()
+		if ratio == 1 || ratio <= 0 {
+			continue
+		}
+		for i := range out {
+			if !out[i].BeginsAt.Before(s.ExecutionDate.Time) {
+				continue
+			}
+			out[i].OpenPrice /= ratio
+			out[i].ClosePrice /= ratio
+			out[i].HighPrice /= ratio
+			out[i].LowPrice /= ratio
+			out[i].Volume = int64(math.Round(float64(out[i].Volume) * ratio))
+		}
+	}
+	return out
+}
+
+// AdjustForDividends returns a copy of rs in which the prices of records
+// before each ex-dividend date are reduced by the proportion of the stock
+// price paid out, so that the price drop on the ex-dividend date disappears.
+// Dividends outside of the range of rs are ignored. As Dividends only returns
+// dividends paid to the account, this cannot adjust instruments whose
+// dividends were not received.
+func AdjustForDividends(rs []HistoricalRecord, ds []Dividend) []HistoricalRecord {
+	out := make([]HistoricalRecord, len(rs))
+	copy(out, rs)
+
+	for _, d := range ds {
+		ex := d.ExDate().Time
+
+		// The adjustment is relative to the last close before the ex-dividend date.
+		last := -1
+		for i, r := range out {
+			if r.BeginsAt.Before(ex) {
+				last = i
+			}
+		}
+		if last < 0 || last == len(out)-1 || out[last].ClosePrice <= 0 {
+			continue
+		}
+
+		factor := 1 - d.Rate/out[last].ClosePrice
+		if factor <= 0 {
+			continue
+		}
+		for i := 0; i <= last; i++ {
+			out[i].OpenPrice *= factor
+			out[i].ClosePrice *= factor
+			out[i].HighPrice *= factor
+			out[i].LowPrice *= factor
+		}
+	}
+	return out
+}
+
+// SplitAdjustedPrice restates a per-share price paid at a point in time, such
+// as a cost basis, in terms of the shares outstanding after the splits.
+func SplitAdjustedPrice(price float64, at time.Time, ss []Split) float64 {
+	for _, s := range ss {
+		if ratio := s.Ratio(); ratio > 0 && at.Before(s.ExecutionDate.Time) {
+			price /= ratio
+		}
+	}
+	return price
+}
+
+// AdjustedHistorical returns historical data for an instrument, adjusted for
+// any splits.
+func (c *Client) AdjustedHistorical(ctx context.Context, interval Interval, span Span, i *Instrument) (Historical, error) {
+	h, err := c.Historical(ctx, interval, span, i.Symbol)
+	if err != nil {
+		return h, err
+	}
+
+	ss, err := c.Splits(ctx, i)
+	if err != nil {
+		return h, err
+	}
+	h.Records = AdjustForSplits(h.Records, ss)
+	return h, nil
+}
@@ -0,0 +1,107 @@
+package roho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplits(t *testing.T) {
+	c := fakeClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/instruments/i1/splits/" {
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"next": null, "results": [
+			{"url": "https://api.robinhood.com/instruments/i1/splits/s2/", "instrument": "https://api.robinhood.com/instruments/i1/", "execution_date": "2021-07-20", "multiplier": "4.00000000", "divisor": "1.00000000"},
+			{"url": "https://api.robinhood.com/instruments/i1/splits/s1/", "instrument": "https://api.robinhood.com/instruments/i1/", "execution_date": "2020-08-31", "multiplier": "1.00000000", "divisor": "10.00000000"}
+		]}`)
+	})
+
+	ss, err := c.Splits(context.Background(), &Instrument{ID: "i1", Splits: "https://api.robinhood.com/instruments/i1/splits/"})
+	if err != nil {
+		t.Fatalf("Splits() returned error: %v", err)
+	}
+
+	got := []float64{}
+	for _, s := range ss {
+		got = append(got, s.Ratio())
+	}
+	if diff := cmp.Diff([]float64{0.1, 4}, got); diff != "" {
+		t.Errorf("Splits() ratios mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdjustForSplits(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2021, 7, d, 0, 0, 0, 0, time.UTC) }
+	rs := []HistoricalRecord{
+		{BeginsAt: day(19), OpenPrice: 400, ClosePrice: 404, HighPrice: 408, LowPrice: 396, Volume: 1000},
+		{BeginsAt: day(20), OpenPrice: 101, ClosePrice: 102, HighPrice: 103, LowPrice: 100, Volume: 4000},
+	}
+	ss := []Split{{ExecutionDate: Date{day(20)}, Multiplier: 4, Divisor: 1}}
+
+	got := AdjustForSplits(rs, ss)
+	want := []HistoricalRecord{
+		{BeginsAt: day(19), OpenPrice: 100, ClosePrice: 101, HighPrice: 102, LowPrice: 99, Volume: 4000},
+		rs[1],
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AdjustForSplits() mismatch (-want +got):\n%s", diff)
+	}
+	if rs[0].OpenPrice != 400 {
+		t.Errorf("AdjustForSplits() modified its input: %+v", rs[0])
+	}
+
+	if got := SplitAdjustedPrice(400, day(1), ss); got != 100 {
+		t.Errorf("SplitAdjustedPrice() before split = %v, want 100", got)
+	}
+	if got := SplitAdjustedPrice(100, day(21), ss); got != 100 {
+		t.Errorf("SplitAdjustedPrice() after split = %v, want 100", got)
+	}
+}
+
+func TestAdjustForDividends(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2021, 8, d, 0, 0, 0, 0, time.UTC) }
+	rs := []HistoricalRecord{
+		{BeginsAt: day(5), OpenPrice: 50, ClosePrice: 50, HighPrice: 50, LowPrice: 50},
+		{BeginsAt: day(6), OpenPrice: 50, ClosePrice: 50, HighPrice: 50, LowPrice: 50},
+		{BeginsAt: day(9), OpenPrice: 49, ClosePrice: 49, HighPrice: 49, LowPrice: 49},
+	}
+	ds := []Dividend{
+		// With T+2 settlement, the ex-dividend date is the 9th.
+		{Rate: 1, RecordDate: Date{day(10)}},
+		// Outside of the range of records.
+		{Rate: 1, RecordDate: Date{day(30)}},
+	}
+
+	got := AdjustForDividends(rs, ds)
+	for i, want := range []float64{49, 49, 49} {
+		if got[i].ClosePrice != want {
+			t.Errorf("AdjustForDividends()[%d].ClosePrice = %v, want %v", i, got[i].ClosePrice, want)
+		}
+	}
+}
+
+func TestDividendExDate(t *testing.T) {
+	tests := []struct {
+		record Date
+		want   Date
+	}{
+		// T+2 settlement: the weekday before the record date.
+		{NewZonedDate(2021, 8, 10, time.UTC), NewZonedDate(2021, 8, 9, time.UTC)},
+		{NewZonedDate(2021, 8, 9, time.UTC), NewZonedDate(2021, 8, 6, time.UTC)},
+		// T+1 settlement: the record date.
+		{NewZonedDate(2024, 8, 12, time.UTC), NewZonedDate(2024, 8, 12, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		if got := (Dividend{RecordDate: tc.record}).ExDate(); !got.Equal(tc.want.Time) {
+			t.Errorf("ExDate() with record date %s = %s, want %s", tc.record, got, tc.want)
+		}
+	}
+}
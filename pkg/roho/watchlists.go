@@ -50,7 +50,7 @@ func (w *Watchlist) Instruments(ctx context.Context) ([]Instrument, error) {
 		// shadow for safe closure access
 		i := i
 		eg.Go(func() error {
-			inst, err := w.c.Instrument(ctx, r.Results[i].Instrument)
+			inst, err := w.c.InstrumentFromURL(ctx, r.Results[i].Instrument)
 			insts[i] = &inst
 			return err
 		})
@@ -0,0 +1,124 @@
+package screener
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Condition is a single screening rule, such as "pe < 20".
+type Condition struct {
+	Metric Metric
+	// Op is one of <, <=, >, >=, = or !=.
+	Op string
+	// Value is the threshold for numeric metrics.
+	Value float64
+	// Text is the value compared against for textual metrics, such as sector.
+	Text string
+}
+
+func (c Condition) String() string {
+	if c.Metric.isText() {
+		return fmt.Sprintf("%s %s %s", c.Metric, c.Op, c.Text)
+	}
+	return fmt.Sprintf("%s %s %g", c.Metric, c.Op, c.Value)
+}
+
+// Match returns whether a candidate satisfies the condition. Candidates
+// lacking the data required are never matched.
+func (c Condition) Match(cd *Candidate) bool {
+	if c.Metric.isText() {
+		eq := strings.EqualFold(c.Metric.Text(cd), c.Text)
+		if c.Op == "!=" {
+			return !eq
+		}
+		return eq
+	}
+
+	v, ok := c.Metric.Value(cd)
+	if !ok {
+		return false
+	}
+
+	switch c.Op {
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case ">":
+		return v > c.Value
+	case ">=":
+		return v >= c.Value
+	case "=":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	}
+	return false
+}
+
+var (
+	clauseRe = regexp.MustCompile(`^\s*(.+?)\s*(<=|>=|!=|=|<|>)\s*(.+?)\s*$`)
+	andRe    = regexp.MustCompile(`(?i)\s+and\s+`)
+)
+
+// suffixes are the multipliers of abbreviated numbers, such as 10B.
+var suffixes = map[string]float64{"K": 1e3, "M": 1e6, "B": 1e9, "T": 1e12}
+
+// parseValue parses a number, optionally abbreviated with K, M, B or T, or
+// followed by a percent sign.
+func parseValue(s string) (float64, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+
+	mult := 1.0
+	if len(s) > 1 {
+		if m, ok := suffixes[strings.ToUpper(s[len(s)-1:])]; ok {
+			mult = m
+			s = s[:len(s)-1]
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	return f * mult, nil
+}
+
+// Parse parses conditions joined by "and", such as
+// "market_cap > 10B and pe < 20 and rsi(14) < 30". Metrics are read as by
+// ParseMetric, so "Market Cap > 10B and PE < 20" is equivalent.
+func Parse(expr string) ([]Condition, error) {
+	cs := []Condition{}
+	if strings.TrimSpace(expr) == "" {
+		return cs, nil
+	}
+
+	for _, clause := range andRe.Split(expr, -1) {
+		ms := clauseRe.FindStringSubmatch(clause)
+		if ms == nil {
+			return nil, fmt.Errorf("invalid condition %q: expected <metric> <op> <value>", clause)
+		}
+
+		m, err := ParseMetric(ms[1])
+		if err != nil {
+			return nil, err
+		}
+
+		c := Condition{Metric: m, Op: ms[2]}
+		if m.isText() {
+			if c.Op != "=" && c.Op != "!=" {
+				return nil, fmt.Errorf("invalid condition %q: %s may only be compared with = or !=", clause, m)
+			}
+			c.Text = ms[3]
+		} else {
+			c.Value, err = parseValue(ms[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid condition %q: %w", clause, err)
+			}
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
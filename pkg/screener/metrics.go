@@ -0,0 +1,231 @@
+package screener
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tstromberg/roho/pkg/indicators"
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// Candidate is a stock being screened.
+type Candidate struct {
+	Symbol      string
+	Fundamental roho.Fundamental
+	Quote       roho.Quote
+	// Daily is a year of daily candles, and is only populated if a condition
+	// requires price history.
+	Daily []roho.HistoricalRecord
+}
+
+// price returns the current price of the candidate.
+func (c *Candidate) price() (float64, bool) {
+	p := c.Quote.Price()
+	return p, p > 0
+}
+
+// metric calculates a numeric property of a candidate, returning false if
+// the data it requires is unavailable.
+type metric struct {
+	calc func(c *Candidate, arg int) (float64, bool)
+	// history is whether the metric requires daily candles.
+	history bool
+	// arg is the default argument for metrics that take one, such as rsi(14).
+	arg int
+}
+
+// fundamental returns a metric reading a field of the candidate's fundamentals.
+// The API reports missing data as 0, so 0 is treated as unavailable.
+func fundamental(f func(roho.Fundamental) float64) metric {
+	return metric{calc: func(c *Candidate, _ int) (float64, bool) {
+		v := f(c.Fundamental)
+		return v, v != 0
+	}}
+}
+
+// metrics are the numeric properties that conditions may be placed on.
+var metrics = map[string]metric{
+	"price":              {calc: func(c *Candidate, _ int) (float64, bool) { return c.price() }},
+	"market_cap":         fundamental(func(f roho.Fundamental) float64 { return f.MarketCap }),
+	"pe":                 fundamental(func(f roho.Fundamental) float64 { return f.PERatio }),
+	"pb":                 fundamental(func(f roho.Fundamental) float64 { return f.PBRatio }),
+	"dividend_yield":     fundamental(func(f roho.Fundamental) float64 { return f.DividendYield }),
+	"volume":             fundamental(func(f roho.Fundamental) float64 { return f.Volume }),
+	"average_volume":     fundamental(func(f roho.Fundamental) float64 { return f.AverageVolume }),
+	"float":              fundamental(func(f roho.Fundamental) float64 { return f.Float }),
+	"shares_outstanding": fundamental(func(f roho.Fundamental) float64 { return f.SharesOutstanding }),
+	"employees":          fundamental(func(f roho.Fundamental) float64 { return float64(f.NumEmployees) }),
+	"change": {calc: func(c *Candidate, _ int) (float64, bool) {
+		p, ok := c.price()
+		if !ok || c.Quote.PreviousClose == 0 {
+			return 0, false
+		}
+		return (p - c.Quote.PreviousClose) / c.Quote.PreviousClose * 100, true
+	}},
+	"pct_from_52w_low": {calc: func(c *Candidate, _ int) (float64, bool) {
+		p, ok := c.price()
+		if !ok || c.Fundamental.Low52Weeks == 0 {
+			return 0, false
+		}
+		return (p - c.Fundamental.Low52Weeks) / c.Fundamental.Low52Weeks * 100, true
+	}},
+	"pct_from_52w_high": {calc: func(c *Candidate, _ int) (float64, bool) {
+		p, ok := c.price()
+		if !ok || c.Fundamental.High52Weeks == 0 {
+			return 0, false
+		}
+		return (c.Fundamental.High52Weeks - p) / c.Fundamental.High52Weeks * 100, true
+	}},
+	"rsi": {history: true, arg: 14, calc: func(c *Candidate, n int) (float64, bool) {
+		return indicators.Last(indicators.RSISeries(indicators.Closes(c.Daily), n))
+	}},
+	"sma": {history: true, arg: 50, calc: func(c *Candidate, n int) (float64, bool) {
+		return indicators.Last(indicators.SMASeries(indicators.Closes(c.Daily), n))
+	}},
+	"pct_from_sma": {history: true, arg: 50, calc: func(c *Candidate, n int) (float64, bool) {
+		sma, ok := indicators.Last(indicators.SMASeries(indicators.Closes(c.Daily), n))
+		p, pok := c.price()
+		if !ok || !pok {
+			return 0, false
+		}
+		return (p - sma) / sma * 100, true
+	}},
+}
+
+// textFields are the textual properties that conditions may be placed on.
+var textFields = map[string]func(roho.Fundamental) string{
+	"sector":   func(f roho.Fundamental) string { return f.Sector },
+	"industry": func(f roho.Fundamental) string { return f.Industry },
+}
+
+// aliases are alternative names of metrics.
+var aliases = map[string]string{
+	"marketcap":     "market_cap",
+	"p/e":           "pe",
+	"pe_ratio":      "pe",
+	"p/b":           "pb",
+	"pb_ratio":      "pb",
+	"yield":         "dividend_yield",
+	"avg_volume":    "average_volume",
+	"num_employees": "employees",
+}
+
+var metricRe = regexp.MustCompile(`^([a-z0-9_]+)(?:\((\d+)\))?$`)
+
+// Metrics returns the names of the metrics that conditions may be placed on.
+func Metrics() []string {
+	ns := []string{}
+	for n := range metrics {
+		ns = append(ns, n)
+	}
+	for n := range textFields {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+// normalize returns the canonical form of a metric as typed by a person, so
+// that "Market Cap" and "RSI (14)" are read as "market_cap" and "rsi(14)".
+func normalize(s string) string {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), "_")
+	s = strings.ReplaceAll(s, "_(", "(")
+	name := s
+	if i := strings.Index(s, "("); i >= 0 {
+		name = s[:i]
+	}
+	if a, ok := aliases[name]; ok {
+		s = a + s[len(name):]
+	}
+	return s
+}
+
+// Metric is a named metric, optionally with an argument, such as "rsi(14)".
+type Metric struct {
+	Name string
+	Arg  int
+}
+
+func (m Metric) String() string {
+	if m.Arg == 0 {
+		return m.Name
+	}
+	return fmt.Sprintf("%s(%d)", m.Name, m.Arg)
+}
+
+// ParseMetric parses a metric such as "market_cap" or "rsi(14)". Case is
+// ignored, and words may be separated by spaces, such as "market cap".
+func ParseMetric(s string) (Metric, error) {
+	ms := metricRe.FindStringSubmatch(normalize(s))
+	if ms == nil {
+		return Metric{}, fmt.Errorf("invalid metric %q", s)
+	}
+
+	name := ms[1]
+	if _, ok := textFields[name]; ok {
+		return Metric{Name: name}, nil
+	}
+
+	m, ok := metrics[name]
+	if !ok {
+		return Metric{}, fmt.Errorf("unknown metric %q", name)
+	}
+
+	arg := m.arg
+	if ms[2] != "" {
+		if m.arg == 0 {
+			return Metric{}, fmt.Errorf("%q does not take an argument", name)
+		}
+		n, err := strconv.Atoi(ms[2])
+		if err != nil || n < 1 {
+			return Metric{}, fmt.Errorf("invalid argument in %q", s)
+		}
+		arg = n
+	}
+	return Metric{Name: name, Arg: arg}, nil
+}
+
+// ParseRankMetric parses a metric to rank results by, such as "rsi(14)".
+// Textual metrics such as "sector" cannot be ranked by, and are rejected.
+func ParseRankMetric(s string) (Metric, error) {
+	m, err := ParseMetric(s)
+	if err != nil {
+		return m, err
+	}
+	if m.isText() {
+		return Metric{}, fmt.Errorf("cannot rank by textual metric %q", m.Name)
+	}
+	return m, nil
+}
+
+// isText returns whether the metric is textual rather than numeric.
+func (m Metric) isText() bool {
+	_, ok := textFields[m.Name]
+	return ok
+}
+
+// needsHistory returns whether the metric requires daily candles.
+func (m Metric) needsHistory() bool {
+	return metrics[m.Name].history
+}
+
+// Value returns the numeric value of the metric for a candidate.
+func (m Metric) Value(c *Candidate) (float64, bool) {
+	mt, ok := metrics[m.Name]
+	if !ok {
+		return 0, false
+	}
+	return mt.calc(c, m.Arg)
+}
+
+// Text returns the textual value of the metric for a candidate.
+func (m Metric) Text(c *Candidate) string {
+	f, ok := textFields[m.Name]
+	if !ok {
+		return ""
+	}
+	return f(c.Fundamental)
+}
@@ -0,0 +1,229 @@
+// Package screener selects stocks from a universe of symbols using
+// declarative conditions on fundamentals, quotes and price history.
+package screener
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// Screen describes which stocks to select, and how to rank them.
+type Screen struct {
+	Conditions []Condition
+	// RankBy, if set, orders results by a metric, ascending unless Descending.
+	// Otherwise results are in universe order.
+	RankBy     *Metric
+	Descending bool
+	// Limit, if non-zero, is the maximum number of results.
+	Limit int
+}
+
+// Result is a stock which passed a screen.
+type Result struct {
+	Symbol string
+	// Rank is the value of the RankBy metric, if any.
+	Rank      float64
+	Candidate *Candidate
+}
+
+// Symbols returns the symbols of the results, in order, such as for use as a
+// matador symbol list.
+func Symbols(rs []Result) []string {
+	syms := []string{}
+	for _, r := range rs {
+		syms = append(syms, r.Symbol)
+	}
+	return syms
+}
+
+// needsHistory returns whether any part of the screen requires daily candles.
+func (s Screen) needsHistory() bool {
+	if s.RankBy != nil && s.RankBy.needsHistory() {
+		return true
+	}
+	for _, c := range s.Conditions {
+		if c.Metric.needsHistory() {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns the candidates which satisfy every condition that can be
+// evaluated, skipping history-based conditions if history is false.
+func (s Screen) filter(cs []*Candidate, history bool) []*Candidate {
+	out := []*Candidate{}
+	for _, cd := range cs {
+		ok := true
+		for _, c := range s.Conditions {
+			if c.Metric.needsHistory() && !history {
+				continue
+			}
+			if !c.Match(cd) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, cd)
+		}
+	}
+	return out
+}
+
+// Evaluate applies the screen to candidates whose data has already been
+// gathered, returning the ranked results.
+func (s Screen) Evaluate(cs []*Candidate) []Result {
+	rs := []Result{}
+	for _, cd := range s.filter(cs, true) {
+		r := Result{Symbol: cd.Symbol, Candidate: cd}
+		if s.RankBy != nil {
+			v, ok := s.RankBy.Value(cd)
+			if !ok {
+				continue
+			}
+			r.Rank = v
+		}
+		rs = append(rs, r)
+	}
+
+	if s.RankBy != nil {
+		sort.SliceStable(rs, func(i, j int) bool {
+			if s.Descending {
+				return rs[i].Rank > rs[j].Rank
+			}
+			return rs[i].Rank < rs[j].Rank
+		})
+	}
+
+	if s.Limit > 0 && len(rs) > s.Limit {
+		rs = rs[:s.Limit]
+	}
+	return rs
+}
+
+// Run gathers fundamentals and quotes for a universe of symbols, such as one
+// from index.Resolve, and applies the screen. Price history is only fetched
+// for the candidates which pass every other condition.
+func Run(ctx context.Context, r *roho.Client, s Screen, universe []string) ([]Result, error) {
+	if len(universe) == 0 {
+		return []Result{}, nil
+	}
+
+	fs, err := r.FundamentalsBySymbol(ctx, universe...)
+	if err != nil {
+		return nil, fmt.Errorf("fundamentals: %w", err)
+	}
+
+	qs, err := r.Quotes(ctx, universe)
+	if err != nil {
+		return nil, fmt.Errorf("quotes: %w", err)
+	}
+	quotes := map[string]roho.Quote{}
+	for _, q := range qs {
+		quotes[strings.ToUpper(q.Symbol)] = q
+	}
+
+	cs := []*Candidate{}
+	seen := map[string]bool{}
+	for _, sym := range universe {
+		sym = strings.ToUpper(sym)
+		if seen[sym] {
+			continue
+		}
+		seen[sym] = true
+		cs = append(cs, &Candidate{Symbol: sym, Fundamental: fs[sym], Quote: quotes[sym]})
+	}
+
+	if s.needsHistory() {
+		cs = s.filter(cs, false)
+		if err := addHistory(ctx, r, cs); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Evaluate(cs), nil
+}
+
+// splitsConcurrency is the number of instruments whose splits are fetched at once.
+const splitsConcurrency = 4
+
+// addHistory attaches a year of daily candles to each candidate, adjusted for
+// splits so that they do not distort indicators.
+func addHistory(ctx context.Context, r *roho.Client, cs []*Candidate) error {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	byS := map[string]*Candidate{}
+	syms := []string{}
+	for _, c := range cs {
+		byS[c.Symbol] = c
+		syms = append(syms, c.Symbol)
+	}
+
+	hs, err := r.HistoricalsParams(ctx, roho.HistoricalParams{Interval: roho.Day, Span: roho.Year}, syms)
+	if err != nil {
+		return fmt.Errorf("historicals: %w", err)
+	}
+
+	ss, err := splits(ctx, r, syms)
+	if err != nil {
+		return err
+	}
+
+	for _, h := range hs {
+		sym := strings.ToUpper(h.Symbol)
+		if c, ok := byS[sym]; ok {
+			c.Daily = roho.AdjustForSplits(h.Records, ss[sym])
+		}
+	}
+	return nil
+}
+
+// splits returns the splits of each symbol, keyed by upper-case symbol.
+func splits(ctx context.Context, r *roho.Client, syms []string) (map[string][]roho.Split, error) {
+	is, err := r.Instruments(ctx, syms)
+	if err != nil {
+		return nil, fmt.Errorf("instruments: %w", err)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, splitsConcurrency)
+
+	out := map[string][]roho.Split{}
+	var firstErr error
+
+	for _, i := range is {
+		// shadow for safe closure access
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ss, err := r.Splits(ctx, &i)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("splits %q: %w", i.Symbol, err)
+				}
+				return
+			}
+			out[strings.ToUpper(i.Symbol)] = ss
+		}()
+	}
+
+	wg.Wait()
+	return out, firstErr
+}
@@ -0,0 +1,217 @@
+package screener
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+func TestParse(t *testing.T) {
+	cs, err := Parse("market_cap > 10B and PE < 20 AND rsi(14) <= 30 and pct_from_52w_low <= 3% and sector = Technology Services")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	got := []string{}
+	for _, c := range cs {
+		got = append(got, c.String())
+	}
+	want := []string{"market_cap > 1e+10", "pe < 20", "rsi(14) <= 30", "pct_from_52w_low <= 3", "sector = Technology Services"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Metrics may be written as a person would.
+	for _, expr := range []string{"market cap > 10B and PE < 20 and RSI(14) < 30", "Market Cap > 10B and p/e < 20 and rsi (14) < 30"} {
+		cs, err := Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", expr, err)
+		}
+		got := []string{}
+		for _, c := range cs {
+			got = append(got, c.String())
+		}
+		if diff := cmp.Diff([]string{"market_cap > 1e+10", "pe < 20", "rsi(14) < 30"}, got); diff != "" {
+			t.Errorf("Parse(%q) mismatch (-want +got):\n%s", expr, diff)
+		}
+	}
+
+	for _, bad := range []string{"pe", "bogus > 1", "pe < cheap", "sector > Tech", "pe(3) < 1"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) returned nil error", bad)
+		}
+	}
+}
+
+func TestParseRankMetric(t *testing.T) {
+	if m, err := ParseRankMetric("RSI (14)"); err != nil || m.String() != "rsi(14)" {
+		t.Errorf("ParseRankMetric(RSI (14)) = %v, %v; want rsi(14), nil", m, err)
+	}
+	if _, err := ParseRankMetric("sector"); err == nil {
+		t.Errorf("ParseRankMetric(sector) returned nil error")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	cs := []*Candidate{
+		{Symbol: "BIG", Fundamental: roho.Fundamental{MarketCap: 50e9, PERatio: 15, Low52Weeks: 100}, Quote: roho.Quote{LastTradePrice: 102, LastExtendedHoursTradePrice: 102}},
+		{Symbol: "PRICEY", Fundamental: roho.Fundamental{MarketCap: 80e9, PERatio: 45, Low52Weeks: 100}, Quote: roho.Quote{LastTradePrice: 101, LastExtendedHoursTradePrice: 101}},
+		{Symbol: "SMALL", Fundamental: roho.Fundamental{MarketCap: 1e9, PERatio: 10, Low52Weeks: 10}, Quote: roho.Quote{LastTradePrice: 10, LastExtendedHoursTradePrice: 10}},
+		{Symbol: "NOPE", Fundamental: roho.Fundamental{MarketCap: 20e9, PERatio: 12, Low52Weeks: 10}, Quote: roho.Quote{LastTradePrice: 20, LastExtendedHoursTradePrice: 20}},
+		{Symbol: "CHEAP", Fundamental: roho.Fundamental{MarketCap: 30e9, PERatio: 8, Low52Weeks: 50}, Quote: roho.Quote{LastTradePrice: 51, LastExtendedHoursTradePrice: 51}},
+	}
+
+	conds, err := Parse("market_cap > 10B and pe < 20 and pct_from_52w_low <= 3")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	rank, err := ParseMetric("pe")
+	if err != nil {
+		t.Fatalf("ParseMetric() returned error: %v", err)
+	}
+
+	got := Symbols(Screen{Conditions: conds, RankBy: &rank}.Evaluate(cs))
+	if diff := cmp.Diff([]string{"CHEAP", "BIG"}, got); diff != "" {
+		t.Errorf("Evaluate() mismatch (-want +got):\n%s", diff)
+	}
+
+	got = Symbols(Screen{Conditions: conds, RankBy: &rank, Descending: true, Limit: 1}.Evaluate(cs))
+	if diff := cmp.Diff([]string{"BIG"}, got); diff != "" {
+		t.Errorf("Evaluate() descending mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMissingFundamentals(t *testing.T) {
+	// Fundamentals the API does not have are reported as 0, and never match.
+	cd := &Candidate{Symbol: "NEW"}
+	for _, expr := range []string{"dividend_yield < 1", "pe < 20", "market_cap < 10B"} {
+		cs, err := Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", expr, err)
+		}
+		if cs[0].Match(cd) {
+			t.Errorf("%s matched a candidate without fundamentals", cs[0])
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	historicals := 0
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fundamentals/":
+			fmt.Fprint(w, `{"results": [{"symbol": "UP", "market_cap": "20000000000"}, {"symbol": "DOWN", "market_cap": "20000000000"}, {"symbol": "TINY", "market_cap": "1000"}]}`)
+		case "/quotes/":
+			fmt.Fprint(w, `{"results": [{"symbol": "UP", "last_trade_price": "30.00", "last_extended_hours_trade_price": "30.00"},
+				{"symbol": "DOWN", "last_trade_price": "10.00", "last_extended_hours_trade_price": "10.00"},
+				{"symbol": "TINY", "last_trade_price": "1.00", "last_extended_hours_trade_price": "1.00"}]}`)
+		case "/quotes/historicals/":
+			historicals++
+			if got := r.URL.Query().Get("symbols"); got != "UP,DOWN" {
+				t.Errorf("historicals requested for %q, want UP,DOWN", got)
+			}
+			fmt.Fprintf(w, `{"results": [{"symbol": "UP", "historicals": [%s]}, {"symbol": "DOWN", "historicals": [%s]}]}`, candles(10, 1), candles(30, -1))
+		case "/instruments/":
+			sym := r.URL.Query().Get("symbol")
+			fmt.Fprintf(w, `{"results": [{"id": %q, "symbol": %q}]}`, strings.ToLower(sym), sym)
+		case "/instruments/up/splits/", "/instruments/down/splits/":
+			fmt.Fprint(w, `{"results": []}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	r := &roho.Client{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Result()
+	})}}
+
+	conds, err := Parse("market_cap > 10B and rsi(14) < 30")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	rs, err := Run(context.Background(), r, Screen{Conditions: conds}, []string{"up", "down", "tiny"})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"DOWN"}, Symbols(rs)); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+	if historicals != 1 {
+		t.Errorf("Run() fetched historicals %d times, want 1", historicals)
+	}
+}
+
+// candles returns 20 daily candles in JSON, starting at price and moving by step each day.
+func TestRunAdjustsForSplits(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fundamentals/":
+			fmt.Fprint(w, `{"results": [{"symbol": "XYZ"}]}`)
+		case "/quotes/":
+			fmt.Fprint(w, `{"results": [{"symbol": "XYZ", "last_trade_price": "50.00"}]}`)
+		case "/quotes/historicals/":
+			// A 2-for-1 split halves the price from September 11th.
+			cs := strings.Split(candles(100, 0), "},{")
+			for i := 10; i < len(cs); i++ {
+				cs[i] = strings.ReplaceAll(cs[i], `"100.00"`, `"50.00"`)
+			}
+			fmt.Fprintf(w, `{"results": [{"symbol": "XYZ", "historicals": [%s]}]}`, strings.Join(cs, "},{"))
+		case "/instruments/":
+			fmt.Fprint(w, `{"results": [{"id": "xyz", "symbol": "XYZ"}]}`)
+		case "/instruments/xyz/splits/":
+			fmt.Fprint(w, `{"results": [{"execution_date": "2021-09-11", "multiplier": "2.0000", "divisor": "1.0000"}]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	r := &roho.Client{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Result()
+	})}}
+
+	// Unadjusted, the 20-day average would be 75.
+	conds, err := Parse("sma(20) < 60")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	rs, err := Run(context.Background(), r, Screen{Conditions: conds}, []string{"xyz"})
+	if err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"XYZ"}, Symbols(rs)); diff != "" {
+		t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func candles(price float64, step float64) string {
+	t := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	cs := []string{}
+	for i := 0; i < 20; i++ {
+		p := price + float64(i)*step
+		cs = append(cs, fmt.Sprintf(`{"begins_at": %q, "open_price": "%.2f", "close_price": "%.2f", "high_price": "%.2f", "low_price": "%.2f", "volume": 100}`,
+			t.AddDate(0, 0, i).Format(time.RFC3339), p, p, p, p))
+	}
+	return strings.Join(cs, ",")
+}
+
+// roundTripFunc serves HTTP requests with a function rather than the network.
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
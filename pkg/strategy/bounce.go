@@ -104,7 +104,7 @@ func (cr *BounceStrategy) determineBuy(ctx context.Context, s *CombinedStock) *T
 	if s.Historical != nil {
 		hs = *s.Historical
 	} else {
-		hs, err = cr.c.Client.Historical(ctx, "5minute", "day", s.Instrument.Symbol)
+		hs, err = cr.c.Client.AdjustedHistorical(ctx, roho.FiveMinute, roho.Day, s.Instrument)
 		if err != nil {
 			klog.Errorf("get historicals failed: %v", err)
 			return nil
@@ -123,11 +123,13 @@ func (cr *BounceStrategy) determineBuy(ctx context.Context, s *CombinedStock) *T
 	ok, bounce := upward(recent)
 	if ok && bounce > 0.01 {
 		klog.Infof("%s: buy now: upward=%v, bounce=%.2f: %v", s.Instrument.Symbol, ok, bounce, recent)
-		return &Trade{
-			Instrument: s.Instrument,
-			Order:      roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: 1, Side: roho.Buy},
-			Reason:     fmt.Sprintf("%.1f%% away from 52wk low of %.2f, %.2f%% bounce", perc, s.Fundamentals.Low52Weeks, bounce),
+		t, ok := cr.c.trade(s,
+			roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: 1, Side: roho.Buy},
+			fmt.Sprintf("%.1f%% away from 52wk low of %.2f, %.2f%% bounce", perc, s.Fundamentals.Low52Weeks, bounce))
+		if !ok {
+			return nil
 		}
+		return &t
 	}
 
 	klog.Infof("%s: wait to buy: upward=%v, bounce=%.2f: %v", s.Instrument.Symbol, ok, bounce, recent)
@@ -172,7 +174,7 @@ func (cr *BounceStrategy) determineSell(ctx context.Context, s *CombinedStock) *
 	if s.Historical != nil {
 		hs = *s.Historical
 	} else {
-		hs, err = cr.c.Client.Historical(ctx, "5minute", "day", s.Instrument.Symbol)
+		hs, err = cr.c.Client.AdjustedHistorical(ctx, roho.FiveMinute, roho.Day, s.Instrument)
 		if err != nil {
 			klog.Errorf("get historicals failed: %v", err)
 			return nil
@@ -191,11 +193,13 @@ func (cr *BounceStrategy) determineSell(ctx context.Context, s *CombinedStock) *
 	if ok && bounce < -0.01 {
 		klog.Infof("%s: sell now: upward=%v, bounce=%.2f: %v", s.Instrument.Symbol, ok, bounce, recent)
 
-		return &Trade{
-			Instrument: s.Instrument,
-			Order:      roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell},
-			Reason:     fmt.Sprintf("%.1f%% away from 52-week high of %.2f, %.2f%% bounce", perc, s.Fundamentals.High52Weeks, bounce),
+		t, ok := cr.c.trade(s,
+			roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell},
+			fmt.Sprintf("%.1f%% away from 52-week high of %.2f, %.2f%% bounce", perc, s.Fundamentals.High52Weeks, bounce))
+		if !ok {
+			return nil
 		}
+		return &t
 	}
 
 	klog.Infof("%s: wait to sell: downward=%v, bounce=%.2f: %v", s.Instrument.Symbol, ok, bounce, recent)
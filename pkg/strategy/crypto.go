@@ -0,0 +1,170 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// CryptoLiveData gathers combined live crypto currency information for a list
+// of currency codes (BTC) or pair symbols (BTC-USD). Crypto currencies are
+// represented as CombinedStocks so that existing strategies can consume them.
+func CryptoLiveData(ctx context.Context, r *roho.Client, codes []string) ([]*CombinedStock, error) {
+	if len(codes) == 0 {
+		return []*CombinedStock{}, nil
+	}
+
+	pairs, err := r.CryptoCurrencyPairs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("crypto currency pairs: %w", err)
+	}
+
+	want := map[string]bool{}
+	for _, c := range codes {
+		want[strings.ToUpper(c)] = true
+	}
+
+	cs := []*CombinedStock{}
+	for _, p := range pairs {
+		// avoid implicit memory aliasing within a for loop
+		p := p
+		if !want[p.AssetCurrency.Code] && !want[p.Symbol] {
+			continue
+		}
+		delete(want, p.AssetCurrency.Code)
+		delete(want, p.Symbol)
+		cs = append(cs, &CombinedStock{
+			CryptoPair: &p,
+			Instrument: &roho.Instrument{ID: p.ID, Name: p.Name, Symbol: p.Symbol, Type: "crypto", URL: p.ID},
+		})
+	}
+
+	if len(want) > 0 {
+		missing := []string{}
+		for c := range want {
+			missing = append(missing, c)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("unknown crypto currencies: %v", missing)
+	}
+
+	return UpdateCryptoData(ctx, r, cs)
+}
+
+// UpdateCryptoData updates crypto currency information.
+func UpdateCryptoData(ctx context.Context, r *roho.Client, cs []*CombinedStock) ([]*CombinedStock, error) {
+	if len(cs) == 0 {
+		return cs, nil
+	}
+
+	pairs := []roho.CryptoCurrencyPair{}
+	for _, s := range cs {
+		pairs = append(pairs, *s.CryptoPair)
+	}
+
+	qs, err := r.CryptoQuotes(ctx, pairs)
+	if err != nil {
+		return nil, fmt.Errorf("crypto quotes: %w", err)
+	}
+
+	quotes := map[string]roho.CryptoQuote{}
+	for _, q := range qs {
+		quotes[q.ID] = q
+	}
+
+	ps, err := r.CryptoPositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("crypto positions: %w", err)
+	}
+
+	positions := map[string]roho.CryptoPosition{}
+	for _, p := range ps {
+		positions[p.Currency.ID] = p
+	}
+
+	now := time.Now()
+	for _, s := range cs {
+		pair := s.CryptoPair
+
+		q, ok := quotes[pair.ID]
+		if !ok {
+			return nil, fmt.Errorf("no crypto quote for %s", pair.Symbol)
+		}
+		s.CryptoQuote = &q
+		s.Quote = &roho.Quote{
+			AskPrice:                    q.AskPrice,
+			BidPrice:                    q.BidPrice,
+			LastTradePrice:              q.MarkPrice,
+			LastExtendedHoursTradePrice: q.MarkPrice,
+			Symbol:                      pair.Symbol,
+			InstrumentID:                pair.ID,
+			InstrumentURL:               pair.ID,
+			HasTraded:                   true,
+			UpdatedAt:                   now,
+		}
+
+		s.CryptoPosition = nil
+		s.Position = nil
+		if p, ok := positions[pair.AssetCurrency.ID]; ok && p.Quantity > 0 {
+			s.CryptoPosition = &p
+			s.Position = &roho.Position{
+				Meta:            p.Meta,
+				AverageBuyPrice: p.AverageCost(),
+				InstrumentURL:   pair.ID,
+				InstrumentID:    pair.ID,
+				Quantity:        p.Quantity,
+			}
+		}
+
+		h, err := r.CryptoHistoricals(ctx, *pair, roho.FiveMinute, roho.Day, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s historicals: %w", pair.Symbol, err)
+		}
+		s.Historical = &h
+
+		// The 52-week range only needs to be fetched once, after which the daily range is folded in.
+		if s.Fundamentals == nil {
+			yh, err := r.CryptoHistoricals(ctx, *pair, roho.Day, roho.Year, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s yearly historicals: %w", pair.Symbol, err)
+			}
+			s.Fundamentals = yearRange(yh.Records)
+		}
+		updateFundamentals(s.Fundamentals, q)
+	}
+
+	return cs, nil
+}
+
+// yearRange returns the 52-week high and low of a year of daily records.
+func yearRange(rs []roho.HistoricalRecord) *roho.Fundamental {
+	f := &roho.Fundamental{}
+	for i, r := range rs {
+		if i == 0 || r.LowPrice < f.Low52Weeks {
+			f.Low52Weeks = r.LowPrice
+		}
+		if r.HighPrice > f.High52Weeks {
+			f.High52Weeks = r.HighPrice
+		}
+	}
+	return f
+}
+
+// updateFundamentals folds a crypto quote into synthesized fundamentals.
+func updateFundamentals(f *roho.Fundamental, q roho.CryptoQuote) {
+	f.Open = q.OpenPrice
+	f.High = q.HighPrice
+	f.Low = q.LowPrice
+	f.Volume = q.Volume
+
+	if f.Low52Weeks == 0 || (q.LowPrice > 0 && q.LowPrice < f.Low52Weeks) {
+		f.Low52Weeks = q.LowPrice
+	}
+	if q.HighPrice > f.High52Weeks {
+		f.High52Weeks = q.HighPrice
+	}
+}
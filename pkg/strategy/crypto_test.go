@@ -0,0 +1,91 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+func TestHiLoCrypto(t *testing.T) {
+	s, err := New(Config{Kind: HiLo, Values: map[string]int64{CryptoDollars: 50}})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	btc := &roho.CryptoCurrencyPair{ID: "btc-usd", Symbol: "BTC-USD"}
+	eth := &roho.CryptoCurrencyPair{ID: "eth-usd", Symbol: "ETH-USD"}
+	cs := []*CombinedStock{
+		{
+			Instrument:     &roho.Instrument{Symbol: "BTC-USD"},
+			CryptoPair:     btc,
+			CryptoPosition: &roho.CryptoPosition{Quantity: 0.02, QuantityAvailable: 0.015},
+			Position:       &roho.Position{Quantity: 0.02, AverageBuyPrice: 30000},
+			Quote:          &roho.Quote{BidPrice: 49900},
+			Fundamentals:   &roho.Fundamental{High52Weeks: 50000},
+		},
+		{
+			Instrument:   &roho.Instrument{Symbol: "ETH-USD"},
+			CryptoPair:   eth,
+			Quote:        &roho.Quote{AskPrice: 2010},
+			Fundamentals: &roho.Fundamental{Low52Weeks: 2000},
+		},
+	}
+
+	want := []Trade{
+		{
+			Instrument:  &roho.Instrument{Symbol: "BTC-USD"},
+			CryptoPair:  btc,
+			CryptoOrder: roho.CryptoOrderOpts{Side: roho.Sell, Type: roho.Limit, Price: 49900, Quantity: 0.015},
+			Reason:      "0.2% away from 52-week high of 50000.00",
+		},
+		{
+			Instrument:  &roho.Instrument{Symbol: "ETH-USD"},
+			CryptoPair:  eth,
+			CryptoOrder: roho.CryptoOrderOpts{Side: roho.Buy, Type: roho.Limit, Price: 2010, AmountInDollars: 50},
+			Reason:      "0.5% away from 52wk low of 2000.00",
+		},
+	}
+
+	got, err := s.Trades(context.Background(), cs)
+	if err != nil {
+		t.Errorf("Trades() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected trade diff: %s", diff)
+	}
+
+	for _, tr := range got {
+		if !tr.IsCrypto() {
+			t.Errorf("%s: IsCrypto() = false, want true", tr.Instrument.Symbol)
+		}
+	}
+
+	// Without a dollar amount, crypto currencies are only sold.
+	s, err = New(Config{Kind: HiLo})
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	got, err = s.Trades(context.Background(), cs)
+	if err != nil {
+		t.Errorf("Trades() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want[:1], got); diff != "" {
+		t.Errorf("unexpected trade diff without %s: %s", CryptoDollars, diff)
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	f := yearRange([]roho.HistoricalRecord{
+		{LowPrice: 30000, HighPrice: 35000},
+		{LowPrice: 29000, HighPrice: 34000},
+		{LowPrice: 40000, HighPrice: 64000},
+	})
+	updateFundamentals(f, roho.CryptoQuote{OpenPrice: 45000, LowPrice: 44000, HighPrice: 65000})
+
+	if f.Low52Weeks != 29000 || f.High52Weeks != 65000 {
+		t.Errorf("52-week range = %.0f-%.0f, want 29000-65000", f.Low52Weeks, f.High52Weeks)
+	}
+}
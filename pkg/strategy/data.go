@@ -2,18 +2,22 @@ package strategy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/tstromberg/roho/pkg/roho"
+	"k8s.io/klog/v2"
 )
 
 // LiveData gathers combined live stock information.
 func LiveData(ctx context.Context, r *roho.Client, syms []string) ([]*CombinedStock, error) {
 	cu := map[string]*CombinedStock{}
 
-	// Using the instruments API is exceptionally slow
+	// Using the instruments API is exceptionally slow, unless the instruments are cached
 	is, err := r.Instruments(ctx, syms)
 	if err != nil {
 		return nil, fmt.Errorf("instruments: %w", err)
@@ -91,6 +95,67 @@ func updateMapData(ctx context.Context, r *roho.Client, cu map[string]*CombinedS
 	return cs, nil
 }
 
+// DefaultMaxQuoteAge is how old a quote may be while its market is open
+// before it is considered stale, if no other age is given to Tradable.
+const DefaultMaxQuoteAge = 5 * time.Minute
+
+// quoteProblem returns why the quote of a stock should not be traded on, if at all.
+func quoteProblem(s *CombinedStock, now time.Time, maxAge time.Duration) error {
+	q := s.Quote
+	if q == nil {
+		return fmt.Errorf("no quote")
+	}
+
+	// Crypto currencies trade around the clock. Their quotes carry no update
+	// time, so their age is measured from when they were fetched.
+	if s.IsCrypto() {
+		if q.IsStale(now, maxAge) {
+			return fmt.Errorf("crypto quote is stale: fetched %s ago", q.Age(now).Round(time.Second))
+		}
+		return nil
+	}
+
+	if q.TradingHalted {
+		return fmt.Errorf("trading is halted")
+	}
+
+	session := roho.SessionAt(now)
+	if session == roho.SessionRegular && !q.HasTraded {
+		return fmt.Errorf("has not traded")
+	}
+
+	// Quotes are expected to be old while the markets are closed.
+	if session != roho.SessionClosed && q.IsStale(now, maxAge) {
+		return fmt.Errorf("quote is stale: last updated %s ago", q.Age(now).Round(time.Second))
+	}
+	return nil
+}
+
+// Tradable sets the QuoteProblem of each stock, treating quotes older than
+// maxAge as stale (or DefaultMaxQuoteAge if 0), and separates the stocks that
+// may be traded on from those with a QuoteProblem.
+func Tradable(cs []*CombinedStock, maxAge time.Duration) (ok []*CombinedStock, excluded []*CombinedStock) {
+	return tradable(cs, time.Now(), maxAge)
+}
+
+func tradable(cs []*CombinedStock, now time.Time, maxAge time.Duration) (ok []*CombinedStock, excluded []*CombinedStock) {
+	if maxAge == 0 {
+		maxAge = DefaultMaxQuoteAge
+	}
+
+	ok = []*CombinedStock{}
+	excluded = []*CombinedStock{}
+	for _, s := range cs {
+		s.QuoteProblem = quoteProblem(s, now, maxAge)
+		if s.QuoteProblem != nil {
+			excluded = append(excluded, s)
+			continue
+		}
+		ok = append(ok, s)
+	}
+	return ok, excluded
+}
+
 // UpdateData updates stock information.
 func UpdateData(ctx context.Context, r *roho.Client, cs []*CombinedStock) ([]*CombinedStock, error) {
 	cu := map[string]*CombinedStock{}
@@ -101,6 +166,216 @@ func UpdateData(ctx context.Context, r *roho.Client, cs []*CombinedStock) ([]*Co
 	return updateMapData(ctx, r, cu)
 }
 
+// AddOptions attaches the option chain, market data for the options matching
+// q, and any open option positions to each of the combined stocks.
+func AddOptions(ctx context.Context, r *roho.Client, cs []*CombinedStock, q roho.ChainQuery) error {
+	byID := map[string]*CombinedStock{}
+	is := []roho.Instrument{}
+	for _, s := range cs {
+		if s.Instrument == nil || s.Instrument.TradableChainID == "" {
+			continue
+		}
+		byID[s.Instrument.ID] = s
+		is = append(is, *s.Instrument)
+	}
+
+	if len(is) > 0 {
+		chs, err := r.OptionChains(ctx, is...)
+		if err != nil {
+			return fmt.Errorf("option chains: %w", err)
+		}
+
+		for _, ch := range chs {
+			for _, ui := range ch.UnderlyingInstruments {
+				s, ok := byID[ui.InstrumentID]
+				if !ok || ch.ID != s.Instrument.TradableChainID {
+					continue
+				}
+				s.OptionChain = ch
+			}
+		}
+	}
+
+	if err := exploreOptions(ctx, cs, q); err != nil {
+		return err
+	}
+
+	hs, err := r.OptionHoldings(ctx)
+	if err != nil {
+		var mde roho.MarketDataError
+		if !errors.As(err, &mde) {
+			return fmt.Errorf("option holdings: %w", err)
+		}
+		klog.Warningf("partial option holdings market data: %v", err)
+	}
+
+	bySym := map[string]*CombinedStock{}
+	for _, s := range cs {
+		if s.Instrument != nil {
+			s.OptionPositions = nil
+			bySym[s.Instrument.Symbol] = s
+		}
+	}
+
+	for _, h := range hs {
+		s, ok := bySym[h.Symbol]
+		if !ok {
+			klog.Infof("ignoring %s option position: not a tracked symbol", h.Symbol)
+			continue
+		}
+		s.OptionPositions = append(s.OptionPositions, h)
+	}
+
+	return nil
+}
+
+// exploreConcurrency is the number of option chains explored at once.
+const exploreConcurrency = 4
+
+// exploreOptions explores the option chain of each stock concurrently, using
+// the price of its quote if one was already fetched, and returns the first
+// failure other than partial market data.
+func exploreOptions(ctx context.Context, cs []*CombinedStock, q roho.ChainQuery) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, exploreConcurrency)
+	var firstErr error
+
+	for _, s := range cs {
+		// shadow for safe closure access
+		s := s
+		if s.OptionChain == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			price := 0.0
+			if s.Quote != nil {
+				price = s.Quote.Price()
+			}
+
+			g, err := s.OptionChain.ExploreAt(ctx, q, price)
+			if err != nil {
+				var mde roho.MarketDataError
+				if g == nil || !errors.As(err, &mde) {
+					mu.Lock()
+					defer mu.Unlock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("explore %s: %w", s.Instrument.Symbol, err)
+					}
+					return
+				}
+				klog.Warningf("%s: partial option market data: %v", s.Instrument.Symbol, err)
+			}
+			s.Options = g
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// AddEarnings attaches past and upcoming earnings reports to each of the
+// combined stocks, so that strategies may avoid holding through earnings.
+// Stocks whose earnings were gathered less than maxAge ago are skipped, as
+// new report dates are scheduled over time. Earnings are not available for
+// every instrument, so failures for a stock are logged and its earnings left
+// as they were, rather than returned.
+func AddEarnings(ctx context.Context, r *roho.Client, cs []*CombinedStock, maxAge time.Duration) error {
+	now := time.Now()
+	syms := []string{}
+	stale := []*CombinedStock{}
+	for _, s := range cs {
+		if s.Instrument == nil || s.IsCrypto() || now.Sub(s.EarningsAt) < maxAge {
+			continue
+		}
+		// Failures are not retried until maxAge has passed either.
+		s.EarningsAt = now
+		syms = append(syms, s.Instrument.Symbol)
+		stale = append(stale, s)
+	}
+
+	if len(syms) == 0 {
+		return nil
+	}
+
+	es, err := r.Earnings(ctx, syms)
+	if err != nil {
+		klog.Warningf("earnings unavailable for %d of %d symbols: %v", len(syms)-len(es), len(syms), err)
+	}
+
+	for _, s := range stale {
+		e, ok := es[strings.ToUpper(s.Instrument.Symbol)]
+		if !ok {
+			klog.Warningf("%s: earnings unavailable", s.Instrument.Symbol)
+			continue
+		}
+		s.Earnings = e
+	}
+	return ctx.Err()
+}
+
+// ReportsEarningsWithin returns whether the stock reports earnings within the
+// next n trading days. It is always false unless AddEarnings was called.
+func (s *CombinedStock) ReportsEarningsWithin(now time.Time, n int) bool {
+	return roho.ReportsWithin(s.Earnings, now, n)
+}
+
+// researchConcurrency is the number of stocks whose news and ratings are fetched at once.
+const researchConcurrency = 4
+
+// AddResearch attaches recent news and analyst ratings to each of the combined
+// stocks, so that strategies may take sentiment into account. Stocks which
+// were researched less than maxAge ago are skipped. Research is not available
+// for every instrument, such as some ETFs, so failures for a stock are logged
+// and its research left as it was, rather than returned.
+func AddResearch(ctx context.Context, r *roho.Client, cs []*CombinedStock, maxAge time.Duration) error {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, researchConcurrency)
+	now := time.Now()
+
+	for _, s := range cs {
+		// shadow for safe closure access
+		s := s
+		if s.Instrument == nil || s.IsCrypto() || now.Sub(s.ResearchedAt) < maxAge {
+			continue
+		}
+		// Failures are not retried until maxAge has passed either.
+		s.ResearchedAt = now
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ns, err := r.News(ctx, s.Instrument.Symbol)
+			if err != nil {
+				klog.Warningf("%s: news unavailable: %v", s.Instrument.Symbol, err)
+			} else {
+				s.News = ns
+			}
+
+			rs, err := r.Ratings(ctx, s.Instrument)
+			if err != nil {
+				klog.Warningf("%s: analyst ratings unavailable: %v", s.Instrument.Symbol, err)
+			} else {
+				s.Ratings = rs
+			}
+		}()
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
+
 // HistoricalData simulates data at a particular point in the past - NOT YET IMPLEMENTED.
 func HistoricalData(_ context.Context, _ *roho.Client, _ []string, _ time.Time) ([]*CombinedStock, error) {
 	cs := []*CombinedStock{}
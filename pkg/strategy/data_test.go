@@ -0,0 +1,103 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+// roundTripFunc serves HTTP requests with a function rather than the network.
+type roundTripFunc func(*http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func TestAddResearch(t *testing.T) {
+	var calls int32
+	h := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch r.URL.Path {
+		case "/midlands/news/AAPL/", "/midlands/news/ETF/":
+			fmt.Fprint(w, `{"results": [{"title": "Headline"}]}`)
+		case "/midlands/ratings/aapl/":
+			fmt.Fprint(w, `{"instrument_id": "aapl", "summary": {"num_buy_ratings": 3}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail": "Not found."}`)
+		}
+	}
+	r := &roho.Client{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w.Result()
+	})}}
+
+	aapl := &CombinedStock{Instrument: &roho.Instrument{ID: "aapl", Symbol: "AAPL"}}
+	etf := &CombinedStock{Instrument: &roho.Instrument{ID: "etf", Symbol: "ETF"}}
+	ctx := context.Background()
+
+	// The ETF has no analyst ratings, which is not fatal.
+	if err := AddResearch(ctx, r, []*CombinedStock{aapl, etf}, time.Hour); err != nil {
+		t.Fatalf("AddResearch() returned error: %v", err)
+	}
+	if aapl.Ratings == nil || aapl.Ratings.Summary.Buy != 3 || len(aapl.News) != 1 {
+		t.Errorf("AAPL research = %+v, %+v", aapl.Ratings, aapl.News)
+	}
+	if etf.Ratings != nil || len(etf.News) != 1 {
+		t.Errorf("ETF research = %+v, %+v; want news only", etf.Ratings, etf.News)
+	}
+
+	// Research is not fetched again until it is maxAge old.
+	before := atomic.LoadInt32(&calls)
+	if err := AddResearch(ctx, r, []*CombinedStock{aapl, etf}, time.Hour); err != nil {
+		t.Fatalf("AddResearch() returned error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls) - before; n != 0 {
+		t.Errorf("AddResearch() made %d calls for fresh research, want 0", n)
+	}
+}
+
+func TestAddEarnings(t *testing.T) {
+	var calls int32
+	r := &roho.Client{Client: &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		atomic.AddInt32(&calls, 1)
+		w := httptest.NewRecorder()
+		if req.URL.Query().Get("symbol") != "AAPL" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail": "Not found."}`)
+			return w.Result()
+		}
+		fmt.Fprint(w, `{"results": [{"symbol": "AAPL", "year": 2021, "quarter": 4, "report": {"date": "2021-10-28", "timing": "pm"}}]}`)
+		return w.Result()
+	})}}
+
+	aapl := &CombinedStock{Instrument: &roho.Instrument{ID: "aapl", Symbol: "AAPL"}}
+	etf := &CombinedStock{Instrument: &roho.Instrument{ID: "etf", Symbol: "ETF"}}
+
+	// The ETF has no earnings, which is not fatal.
+	if err := AddEarnings(context.Background(), r, []*CombinedStock{aapl, etf}, time.Hour); err != nil {
+		t.Fatalf("AddEarnings() returned error: %v", err)
+	}
+	if len(aapl.Earnings) != 1 || aapl.Earnings[0].Quarter != 4 {
+		t.Errorf("AAPL earnings = %+v", aapl.Earnings)
+	}
+	if len(etf.Earnings) != 0 {
+		t.Errorf("ETF earnings = %+v, want none", etf.Earnings)
+	}
+
+	// Earnings are not fetched again until they are maxAge old.
+	before := atomic.LoadInt32(&calls)
+	if err := AddEarnings(context.Background(), r, []*CombinedStock{aapl, etf}, time.Hour); err != nil {
+		t.Fatalf("AddEarnings() returned error: %v", err)
+	}
+	if n := atomic.LoadInt32(&calls) - before; n != 0 {
+		t.Errorf("AddEarnings() made %d calls for fresh earnings, want 0", n)
+	}
+}
@@ -36,11 +36,9 @@ func (cr *HiLoStrategy) Trades(_ context.Context, cs []*CombinedStock) ([]Trade,
 			}
 
 			if perc <= 0.9 {
-				ts = append(ts, Trade{
-					Instrument: s.Instrument,
-					Order:      roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: 1, Side: roho.Buy},
-					Reason:     fmt.Sprintf("%.1f%% away from 52wk low of %.2f", perc, s.Fundamentals.Low52Weeks),
-				})
+				ts = cr.c.appendTrade(ts, s,
+					roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: 1, Side: roho.Buy},
+					fmt.Sprintf("%.1f%% away from 52wk low of %.2f", perc, s.Fundamentals.Low52Weeks))
 			}
 			continue
 		}
@@ -63,11 +61,9 @@ func (cr *HiLoStrategy) Trades(_ context.Context, cs []*CombinedStock) ([]Trade,
 				continue
 			}
 
-			ts = append(ts, Trade{
-				Instrument: s.Instrument,
-				Order:      roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell},
-				Reason:     fmt.Sprintf("%.1f%% away from 52-week high of %.2f", perc, s.Fundamentals.High52Weeks),
-			})
+			ts = cr.c.appendTrade(ts, s,
+				roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell},
+				fmt.Sprintf("%.1f%% away from 52-week high of %.2f", perc, s.Fundamentals.High52Weeks))
 			continue
 		}
 	}
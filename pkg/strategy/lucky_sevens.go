@@ -33,7 +33,7 @@ func (cr *LuckySevensStrategy) Trades(_ context.Context, cs []*CombinedStock) ([
 			bid := fmt.Sprintf("%.2f", s.Quote.BidPrice)
 			klog.Infof("%q bid=%q", s.Instrument.Symbol, bid)
 			if eightRe.MatchString(bid) {
-				ts = append(ts, Trade{Instrument: s.Instrument, Order: roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell}})
+				ts = cr.c.appendTrade(ts, s, roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(p.Quantity), Side: roho.Sell}, "")
 				continue
 			}
 		}
@@ -42,7 +42,7 @@ func (cr *LuckySevensStrategy) Trades(_ context.Context, cs []*CombinedStock) ([
 		klog.Infof("%q ask=%q", s.Instrument.Symbol, ask)
 		if sevenRe.MatchString(ask) {
 			q := uint64(math.Round(777.77 / s.Quote.AskPrice))
-			ts = append(ts, Trade{Instrument: s.Instrument, Order: roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: q, Side: roho.Buy}})
+			ts = cr.c.appendTrade(ts, s, roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: q, Side: roho.Buy}, "")
 		}
 	}
 
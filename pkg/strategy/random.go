@@ -40,7 +40,7 @@ func (cr *RandomStrategy) Trades(_ context.Context, cs []*CombinedStock) ([]Trad
 		if nb.Int64() != luckyNumber {
 			continue
 		}
-		ts = append(ts, Trade{Instrument: s.Instrument, Order: roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(s.Position.Quantity), Side: roho.Sell}})
+		ts = cr.c.appendTrade(ts, s, roho.OrderOpts{Price: s.Quote.BidPrice, Quantity: uint64(s.Position.Quantity), Side: roho.Sell}, "")
 	}
 
 	// Now buy
@@ -52,7 +52,7 @@ func (cr *RandomStrategy) Trades(_ context.Context, cs []*CombinedStock) ([]Trad
 		if nb.Int64() != luckyNumber {
 			continue
 		}
-		ts = append(ts, Trade{Instrument: s.Instrument, Order: roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: uint64(luckyNumber), Side: roho.Buy}})
+		ts = cr.c.appendTrade(ts, s, roho.OrderOpts{Price: s.Quote.AskPrice, Quantity: uint64(luckyNumber), Side: roho.Buy}, "")
 	}
 
 	return ts, nil
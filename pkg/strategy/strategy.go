@@ -3,8 +3,10 @@ package strategy
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/tstromberg/roho/pkg/roho"
+	"k8s.io/klog/v2"
 )
 
 var (
@@ -18,7 +20,39 @@ var (
 type Trade struct {
 	Instrument *roho.Instrument
 	Order      roho.OrderOpts
-	Reason     string
+	// OptionLegs, if set, makes this an options order placed using OptionOrder rather than Order.
+	OptionLegs  []roho.OptionLeg
+	OptionOrder roho.OptionsOrderOpts
+	// CryptoPair, if set, makes this a crypto order placed using CryptoOrder rather than Order.
+	CryptoPair  *roho.CryptoCurrencyPair
+	CryptoOrder roho.CryptoOrderOpts
+	Reason      string
+}
+
+// IsCrypto returns whether the trade is a crypto currency order.
+func (t Trade) IsCrypto() bool {
+	return t.CryptoPair != nil
+}
+
+// IsOption returns whether the trade is an options order.
+func (t Trade) IsOption() bool {
+	return len(t.OptionLegs) > 0
+}
+
+// Side returns whether the trade buys or sells. Options orders buy if they are
+// opened for a debit, and sell if they are opened for a credit.
+func (t Trade) Side() roho.OrderSide {
+	switch {
+	case t.IsCrypto():
+		return t.CryptoOrder.Side
+	case t.IsOption():
+		if t.OptionOrder.Direction == roho.Credit {
+			return roho.Sell
+		}
+		return roho.Buy
+	default:
+		return t.Order.Side
+	}
 }
 
 type CombinedStock struct {
@@ -27,6 +61,36 @@ type CombinedStock struct {
 	Fundamentals *roho.Fundamental
 	Position     *roho.Position
 	Historical   *roho.Historical
+	// QuoteProblem is why the quote should not be traded on, such as a
+	// trading halt or a stale quote, if any. It is set by Tradable.
+	QuoteProblem error
+
+	// Earnings reports are only populated by AddEarnings.
+	Earnings   []roho.Earnings
+	EarningsAt time.Time
+
+	// News and analyst ratings are only populated by AddResearch, and may be
+	// missing for instruments without coverage.
+	News         []roho.NewsItem
+	Ratings      *roho.Ratings
+	ResearchedAt time.Time
+
+	// Options data is only populated by AddOptions.
+	OptionChain     *roho.OptionChain
+	Options         *roho.ChainGrid
+	OptionPositions []roho.OptionHolding
+
+	// Crypto data is only populated by CryptoLiveData. The equity fields above
+	// are populated from it so that strategies may treat crypto currencies as
+	// stocks.
+	CryptoPair     *roho.CryptoCurrencyPair
+	CryptoQuote    *roho.CryptoQuote
+	CryptoPosition *roho.CryptoPosition
+}
+
+// IsCrypto returns whether the stock is really a crypto currency.
+func (s *CombinedStock) IsCrypto() bool {
+	return s.CryptoPair != nil
 }
 
 // Strategy is an interface for executing stock strategies.
@@ -41,6 +105,52 @@ type Config struct {
 	Values map[string]int64
 }
 
+// CryptoDollars is the Config value holding the dollar amount of each crypto
+// currency buy. Crypto currencies are not bought unless it is set.
+const CryptoDollars = "crypto-dollars"
+
+// trade returns a Trade for a stock. For crypto currencies, the equity order
+// is converted to a crypto order: sells are for the entire position, and buys
+// are for the CryptoDollars config value. It returns false if the trade should
+// not be placed, such as a crypto buy without CryptoDollars set, or a crypto
+// sell without a position.
+func (c Config) trade(s *CombinedStock, o roho.OrderOpts, reason string) (Trade, bool) {
+	if !s.IsCrypto() {
+		return Trade{Instrument: s.Instrument, Order: o, Reason: reason}, true
+	}
+
+	co := roho.CryptoOrderOpts{
+		Side:        o.Side,
+		Type:        roho.Limit,
+		Price:       o.Price,
+		TimeInForce: roho.GTC,
+	}
+
+	if o.Side == roho.Sell {
+		if s.CryptoPosition == nil {
+			return Trade{}, false
+		}
+		co.Quantity = s.CryptoPosition.QuantityAvailable
+	} else {
+		d := c.Values[CryptoDollars]
+		if d <= 0 {
+			klog.Warningf("not buying %s: %s is not set", s.CryptoPair.Symbol, CryptoDollars)
+			return Trade{}, false
+		}
+		co.AmountInDollars = float64(d)
+	}
+
+	return Trade{Instrument: s.Instrument, CryptoPair: s.CryptoPair, CryptoOrder: co, Reason: reason}, true
+}
+
+// appendTrade appends a Trade for a stock to ts, unless it should not be placed.
+func (c Config) appendTrade(ts []Trade, s *CombinedStock, o roho.OrderOpts, reason string) []Trade {
+	if t, ok := c.trade(s, o, reason); ok {
+		return append(ts, t)
+	}
+	return ts
+}
+
 // New returns a new strategy manager.
 func New(c Config) (Strategy, error) {
 	switch c.Kind {
@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tstromberg/roho/pkg/roho"
+)
+
+func TestTradeSide(t *testing.T) {
+	leg := roho.OptionLeg{Instrument: &roho.OptionInstrument{URL: "c100"}, Side: roho.Sell, PositionEffect: "open"}
+
+	tests := []struct {
+		name string
+		t    Trade
+		want roho.OrderSide
+	}{
+		{name: "equity buy", t: Trade{Order: roho.OrderOpts{Side: roho.Buy}}, want: roho.Buy},
+		{name: "equity sell", t: Trade{Order: roho.OrderOpts{Side: roho.Sell}}, want: roho.Sell},
+		{name: "option debit", t: Trade{OptionLegs: []roho.OptionLeg{leg}, OptionOrder: roho.OptionsOrderOpts{Direction: roho.Debit}}, want: roho.Buy},
+		{name: "option credit", t: Trade{OptionLegs: []roho.OptionLeg{leg}, OptionOrder: roho.OptionsOrderOpts{Direction: roho.Credit}}, want: roho.Sell},
+	}
+
+	for _, tc := range tests {
+		if got := tc.t.Side(); got != tc.want {
+			t.Errorf("%s: Side() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestQuoteProblem(t *testing.T) {
+	// 10:00 on a Tuesday in New York, during regular trading.
+	open := time.Date(2021, 9, 14, 14, 0, 0, 0, time.UTC)
+	// 22:00 on the same day, after extended trading.
+	closed := time.Date(2021, 9, 15, 2, 0, 0, 0, time.UTC)
+	btc := &roho.CryptoCurrencyPair{Symbol: "BTC-USD"}
+
+	tests := []struct {
+		name    string
+		s       CombinedStock
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "fresh", s: CombinedStock{Quote: &roho.Quote{UpdatedAt: open.Add(-time.Minute), HasTraded: true}}, now: open},
+		{name: "stale", s: CombinedStock{Quote: &roho.Quote{UpdatedAt: open.Add(-time.Hour), HasTraded: true}}, now: open, wantErr: true},
+		{name: "old while closed", s: CombinedStock{Quote: &roho.Quote{UpdatedAt: open, HasTraded: true}}, now: closed},
+		{name: "halted", s: CombinedStock{Quote: &roho.Quote{UpdatedAt: open, HasTraded: true, TradingHalted: true}}, now: open, wantErr: true},
+		{name: "not traded", s: CombinedStock{Quote: &roho.Quote{UpdatedAt: open}}, now: open, wantErr: true},
+		{name: "no quote", s: CombinedStock{}, now: open, wantErr: true},
+		{name: "fresh crypto", s: CombinedStock{CryptoPair: btc, Quote: &roho.Quote{UpdatedAt: closed.Add(-time.Minute)}}, now: closed},
+		{name: "stale crypto", s: CombinedStock{CryptoPair: btc, Quote: &roho.Quote{UpdatedAt: closed.Add(-time.Hour)}}, now: closed, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		err := quoteProblem(&tc.s, tc.now, DefaultMaxQuoteAge)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: quoteProblem() = %v, want error: %v", tc.name, err, tc.wantErr)
+		}
+	}
+
+	stale := tests[1].s
+	ok, excluded := tradable([]*CombinedStock{&stale}, open, 0)
+	if len(ok) != 0 || len(excluded) != 1 || excluded[0].QuoteProblem == nil {
+		t.Errorf("tradable() = %d ok, %d excluded; want 0, 1 with a QuoteProblem", len(ok), len(excluded))
+	}
+
+	// A longer maximum age makes the hour old quote tradable.
+	ok, _ = tradable([]*CombinedStock{&stale}, open, 2*time.Hour)
+	if len(ok) != 1 || ok[0].QuoteProblem != nil {
+		t.Errorf("tradable() with a 2h maximum age = %d ok, want 1", len(ok))
+	}
+}
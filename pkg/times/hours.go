@@ -0,0 +1,102 @@
+package times
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Hours are the trading hours of a market on a single day.
+type Hours struct {
+	// Date is midnight of the day in New York.
+	Date time.Time
+	// IsOpen is false on weekends and holidays, when the other times are zero.
+	IsOpen bool
+	// Opens and Closes bound regular trading, and may be early on half days.
+	Opens  time.Time
+	Closes time.Time
+	// ExtendedOpens and ExtendedCloses bound the broker's extended-hours
+	// trading window.
+	ExtendedOpens  time.Time
+	ExtendedCloses time.Time
+}
+
+// HoursSource is an authoritative source of market hours, aware of holidays
+// and early closes, such as one backed by a broker API.
+type HoursSource interface {
+	Hours(date time.Time) (Hours, error)
+}
+
+var (
+	sourceMu sync.Mutex
+	source   HoursSource
+	// failing is whether the source failed when last consulted, so that
+	// failures are only logged once.
+	failing bool
+)
+
+// SetHoursSource sets the source of market hours used by this package. If nil,
+// or if the source fails, every weekday is assumed to have regular hours.
+func SetHoursSource(s HoursSource) {
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	source = s
+	failing = false
+}
+
+// DefaultHours returns the hours assumed for a day in the absence of an
+// HoursSource: weekdays are open with regular hours, and weekends closed.
+func DefaultHours(date time.Time) Hours {
+	d := date.In(nyLoc())
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, nyLoc())
+	h := Hours{Date: day}
+	if !IsWeekDay(day) {
+		return h
+	}
+
+	at := func(m int) time.Time { return day.Add(time.Duration(m) * time.Minute) }
+	h.IsOpen = true
+	h.Opens = at(MinOpen)
+	h.Closes = at(MinClose)
+	h.ExtendedOpens = at(MinRHExtendedOpen)
+	h.ExtendedCloses = at(MinRHExtendedClose)
+	return h
+}
+
+// HoursOn returns the market hours for the day containing date, consulting the
+// HoursSource if one is set, and otherwise DefaultHours.
+func HoursOn(date time.Time) Hours {
+	sourceMu.Lock()
+	s := source
+	sourceMu.Unlock()
+
+	if s == nil {
+		return DefaultHours(date)
+	}
+
+	h, err := s.Hours(date)
+
+	sourceMu.Lock()
+	defer sourceMu.Unlock()
+	if err == nil {
+		failing = false
+		return h
+	}
+
+	if !failing {
+		klog.Warningf("market hours unavailable, assuming regular hours until they are: %v", err)
+		failing = true
+	}
+	return DefaultHours(date)
+}
+
+// IsTradingDay returns whether the market is open at all on the day of t.
+func IsTradingDay(t time.Time) bool {
+	return HoursOn(t).IsOpen
+}
+
+// within returns whether t falls within [start, end).
+func within(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
@@ -0,0 +1,84 @@
+package times
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSource is an HoursSource closed on weekends and the listed dates, which
+// fails if err is set.
+type fakeSource struct {
+	holidays map[string]bool
+	err      error
+}
+
+func (f *fakeSource) Hours(date time.Time) (Hours, error) {
+	if f.err != nil {
+		return Hours{}, f.err
+	}
+	h := DefaultHours(date)
+	if f.holidays[h.Date.Format("2006-01-02")] {
+		return Hours{Date: h.Date}, nil
+	}
+	return h, nil
+}
+
+func TestDefaultHours(t *testing.T) {
+	// 22:00 UTC on Tuesday is 18:00 in New York.
+	h := DefaultHours(time.Date(2021, 9, 14, 22, 0, 0, 0, time.UTC))
+	if !h.IsOpen {
+		t.Fatalf("DefaultHours() on a Tuesday is closed")
+	}
+	want := map[string]time.Time{
+		"Date":           time.Date(2021, 9, 14, 4, 0, 0, 0, time.UTC),
+		"Opens":          time.Date(2021, 9, 14, 13, 30, 0, 0, time.UTC),
+		"Closes":         time.Date(2021, 9, 14, 20, 0, 0, 0, time.UTC),
+		"ExtendedOpens":  time.Date(2021, 9, 14, 13, 0, 0, 0, time.UTC),
+		"ExtendedCloses": time.Date(2021, 9, 14, 22, 0, 0, 0, time.UTC),
+	}
+	got := map[string]time.Time{"Date": h.Date, "Opens": h.Opens, "Closes": h.Closes, "ExtendedOpens": h.ExtendedOpens, "ExtendedCloses": h.ExtendedCloses}
+	for k, w := range want {
+		if !got[k].Equal(w) {
+			t.Errorf("DefaultHours().%s = %s, want %s", k, got[k].UTC(), w)
+		}
+	}
+
+	// 02:00 UTC on Saturday is still Friday in New York.
+	if !DefaultHours(time.Date(2021, 9, 18, 2, 0, 0, 0, time.UTC)).IsOpen {
+		t.Errorf("DefaultHours() on Friday evening in New York is closed")
+	}
+	if DefaultHours(time.Date(2021, 9, 18, 15, 0, 0, 0, time.UTC)).IsOpen {
+		t.Errorf("DefaultHours() on Saturday is open")
+	}
+}
+
+func TestHoursSource(t *testing.T) {
+	thanksgiving := time.Date(2021, 11, 25, 15, 0, 0, 0, time.UTC)
+	if !IsTradingDay(thanksgiving) {
+		t.Errorf("IsTradingDay(Thanksgiving) = false without an HoursSource")
+	}
+
+	f := &fakeSource{holidays: map[string]bool{"2021-11-25": true}}
+	SetHoursSource(f)
+	defer SetHoursSource(nil)
+
+	if IsTradingDay(thanksgiving) {
+		t.Errorf("IsTradingDay(Thanksgiving) = true with an HoursSource")
+	}
+
+	// Wednesday, then Friday and Monday around the holiday.
+	wed := time.Date(2021, 11, 24, 0, 0, 0, 0, time.UTC)
+	if got := AddTradingDays(wed, 2); !got.Equal(time.Date(2021, 11, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddTradingDays(%s, 2) = %s, want Monday", wed, got)
+	}
+
+	// A failing source falls back to the default hours.
+	f.err = errors.New("unavailable")
+	if !IsTradingDay(thanksgiving) {
+		t.Errorf("IsTradingDay(Thanksgiving) = false with a failing HoursSource")
+	}
+	if h := HoursOn(time.Date(2021, 11, 24, 15, 0, 0, 0, time.UTC)); !h.IsOpen || h.Closes.IsZero() {
+		t.Errorf("HoursOn() with a failing HoursSource = %+v, want regular hours", h)
+	}
+}
@@ -55,68 +55,91 @@ func NextWeekday() time.Time {
 // IsRegularTradingTime returns whether or not the markets are currently open
 // for regular trading.
 func IsRegularTradingTime() bool {
-	now := nyMinute()
-	return MinOpen <= now && now < MinClose
+	now := time.Now()
+	h := HoursOn(now)
+	return h.IsOpen && within(now, h.Opens, h.Closes)
 }
 
 // IsRobinhoodExtendedTradingTime returns whether or not trades can still be
 // placed during the robinhood gold extended trading hours.
 func IsRobinhoodExtendedTradingTime() bool {
-	now := nyMinute()
-	return MinRHExtendedOpen <= now && now < MinRHExtendedClose
+	now := time.Now()
+	h := HoursOn(now)
+	return h.IsOpen && within(now, h.ExtendedOpens, h.ExtendedCloses)
 }
 
 // IsExtendedTradingTime returns whether or not extended hours equity will be
 // updated because extended-hours trades may still be allowed in the markets.
 func IsExtendedTradingTime() bool {
 	now := nyMinute()
-	return MinExtendedOpen <= now && now < MinExtendedClose
+	return IsTradingDay(time.Now()) && MinExtendedOpen <= now && now < MinExtendedClose
 }
 
-// nextWeekdayHourMinuteNY returns the time.Time of the next h/m occurrence on a weekday in New York.
-func nextWeekdayHourMinuteNY(h, m int) time.Time {
-	now := time.Now()
+// maxClosedDays is the longest run of days the market may be closed for.
+const maxClosedDays = 14
 
-	if h*60+m <= MinuteOfDay(now) {
-		now = NextWeekday()
+// nextEvent returns the next time of a market event on a trading day, as
+// selected from each day's hours by f.
+func nextEvent(f func(Hours) time.Time) time.Time {
+	now := time.Now()
+	for i := 0; i < maxClosedDays; i++ {
+		h := HoursOn(now.AddDate(0, 0, i))
+		if !h.IsOpen {
+			continue
+		}
+		if t := f(h); t.After(now) {
+			return t
+		}
 	}
-
-	return time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, nyLoc())
+	return f(DefaultHours(NextWeekday()))
 }
 
 // NextMarketOpen returns the time of the next opening bell, when regular
 // trading begins.
 func NextMarketOpen() time.Time {
-	return nextWeekdayHourMinuteNY(9, 30)
+	return nextEvent(func(h Hours) time.Time { return h.Opens })
 }
 
 // NextMarketExtendedOpen returns the time of the next extended opening time,
 // when stock equity may begin to fluctuate again.
 func NextMarketExtendedOpen() time.Time {
-	return nextWeekdayHourMinuteNY(HrExtendedOpen, 0)
+	return nextEvent(func(h Hours) time.Time { return h.Date.Add(HrExtendedOpen * time.Hour) })
 }
 
 // NextRobinhoodExtendedOpen returns the time of the next robinhood extended
 // opening time, when robinhood users can make trades.
 func NextRobinhoodExtendedOpen() time.Time {
-	return nextWeekdayHourMinuteNY(HrRHExtendedOpen, 0)
+	return nextEvent(func(h Hours) time.Time { return h.ExtendedOpens })
 }
 
 // NextMarketClose returns the time of the next market close.
 func NextMarketClose() time.Time {
-	return nextWeekdayHourMinuteNY(HrClose, 0)
+	return nextEvent(func(h Hours) time.Time { return h.Closes })
 }
 
 // NextRobinhoodExtendedClose returns the time of the next robinhood extended
 // closing time, when robinhood users must place their last extended-hours
 // trade.
 func NextRobinhoodExtendedClose() time.Time {
-	return nextWeekdayHourMinuteNY(HrRHExtendedClose, 0)
+	return nextEvent(func(h Hours) time.Time { return h.ExtendedCloses })
 }
 
 // NextMarketExtendedClose returns the time of the next extended market close,
 // when stock equity numbers will stop being updated until the next extended
 // open.
 func NextMarketExtendedClose() time.Time {
-	return nextWeekdayHourMinuteNY(HrRHExtendedClose, 0)
+	return nextEvent(func(h Hours) time.Time { return h.ExtendedCloses })
+}
+
+// AddTradingDays returns the time n trading days after t, skipping weekends
+// and, if an HoursSource is set, market holidays. The calendar date of t is
+// used as-is, whatever its location.
+func AddTradingDays(t time.Time, n int) time.Time {
+	for n > 0 {
+		t = t.AddDate(0, 0, 1)
+		if IsTradingDay(time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, nyLoc())) {
+			n--
+		}
+	}
+	return t
 }